@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"goipcalc/pkg/output"
+	"os"
+)
+
+// excludeCMD handle "goipcalc exclude PARENT EXCLUDE...", printing the
+// minimal list of prefixes of PARENT without EXCLUDE ones.
+func excludeCMD(args []string) int {
+	fs := flag.NewFlagSet("exclude", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: goipcalc exclude [OPTIONS] ADDR/PLEN EXCLUDE/PLEN...")
+		fmt.Fprintln(os.Stderr, "Examples:")
+		fmt.Fprintln(os.Stderr, "  goipcalc exclude 10.0.0.0/8 10.1.2.0/24")
+		fmt.Fprintln(os.Stderr, "  goipcalc exclude 2001:db8::/32 2001:db8:1::/48 2001:db8:ff00::/40")
+		fmt.Fprintln(os.Stderr, "Options:")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	parseInterspersed(fs, args)
+
+	eargs := joinMaskArgs(fs.Args(), common.isMask())
+	if len(eargs) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: expected ADDR/PLEN and at least one EXCLUDE/PLEN.")
+		fs.Usage()
+		return 1
+	}
+
+	parent, err := common.parseOne(eargs[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %q: %v\n", eargs[0], err)
+		return 1
+	}
+	// skipping invalid exclusion would print too much, so it fail
+	var excl []ipcalc.IP
+	for _, v := range eargs[1:] {
+		ips, _, err := common.parseIPs(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %q: %v\n", v, err)
+			return 1
+		}
+		excl = append(excl, ips...)
+	}
+
+	ips, err := ipcalc.Exclude(parent, excl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// nothing left is a valid answer, JSON has empty results
+	if len(ips) == 0 && !*common.jsonOut {
+		fmt.Fprintf(os.Stderr, "note: nothing left, %s is fully excluded\n", parent.GetAddrMask())
+	}
+	seq := func(yield func(output.Prettier) bool) {
+		for _, ip := range ips {
+			if !yield(ip) {
+				return
+			}
+		}
+	}
+	if err := output.StreamOutput(os.Stdout, *common.jsonOut, *common.jsonIndent, common.format(), seq); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"os"
+)
+
+// Exit status of contains and overlaps commands, for use in scripts.
+const (
+	exitYes   = 0
+	exitNo    = 1
+	exitError = 2
+)
+
+// queryFlagSet return flag set of contains/overlaps command with
+// parse flags and quiet flag.
+func queryFlagSet(name, usage string, examples ...string) (*flag.FlagSet, *bool, *parseFlags) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: goipcalc "+name+" [OPTIONS] "+usage)
+		fmt.Fprintln(os.Stderr, "Examples:")
+		for _, e := range examples {
+			fmt.Fprintln(os.Stderr, "  goipcalc "+e)
+		}
+		fmt.Fprintln(os.Stderr, "Exit status is 0 when true, 1 when false and 2 on error.")
+		fmt.Fprintln(os.Stderr, "Options:")
+		fs.PrintDefaults()
+	}
+	quiet := fs.Bool("quiet", false, "print nothing, only set exit status")
+	fs.BoolVar(quiet, "q", false, "shorthand for -quiet")
+	return fs, quiet, addParseFlags(fs)
+}
+
+// containsCMD handle "goipcalc contains PREFIX ADDR|PREFIX...". Exit
+// status is 0 when PREFIX contains every argument.
+func containsCMD(args []string) int {
+	fs, quiet, pf := queryFlagSet("contains", "ADDR/PLEN ADDR|ADDR/PLEN...",
+		"contains 10.0.0.0/8 10.1.2.3 10.200.0.0/16",
+		"contains -q 2001:db8::/32 2001:db8:1::/48 && echo inside")
+	parseInterspersed(fs, args)
+
+	qargs := joinMaskArgs(fs.Args(), pf.isMask())
+	if len(qargs) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: expected ADDR/PLEN and at least one ADDR or ADDR/PLEN.")
+		fs.Usage()
+		return exitError
+	}
+
+	parent, err := pf.parseOne(qargs[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %q: %v\n", qargs[0], err)
+		return exitError
+	}
+
+	status := exitYes
+	for _, v := range qargs[1:] {
+		ips, _, err := pf.parseIPs(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %q: %v\n", v, err)
+			return exitError
+		}
+		for _, ip := range ips {
+			ok := parent.Contains(ip)
+			if !ok {
+				status = exitNo
+			}
+			if *quiet {
+				continue
+			}
+			if ok {
+				fmt.Printf("%s contains %s\n", parent.GetAddrMask(), ip.GetAddrMask())
+			} else {
+				fmt.Printf("%s does not contain %s\n", parent.GetAddrMask(), ip.GetAddrMask())
+			}
+		}
+	}
+	return status
+}
+
+// overlapsCMD handle "goipcalc overlaps A B". Exit status is 0 when A
+// and B have a common address.
+func overlapsCMD(args []string) int {
+	fs, quiet, pf := queryFlagSet("overlaps", "ADDR/PLEN ADDR/PLEN",
+		"overlaps 10.0.0.0/24 10.0.0.128/25",
+		"overlaps -q 192.168.0.0/16 192.168.10.0/24 || echo free")
+	parseInterspersed(fs, args)
+
+	qargs := joinMaskArgs(fs.Args(), pf.isMask())
+	if len(qargs) != 2 {
+		fmt.Fprintln(os.Stderr, "Error: expected two ADDR/PLEN.")
+		fs.Usage()
+		return exitError
+	}
+
+	var ips [2]ipcalc.IP
+	for i, v := range qargs {
+		ip, err := pf.parseOne(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %q: %v\n", v, err)
+			return exitError
+		}
+		ips[i] = ip
+	}
+
+	a, b := ips[0], ips[1]
+	ok := a.Overlaps(b)
+	if !*quiet {
+		verb := "overlaps"
+		if !ok {
+			verb = "does not overlap"
+		}
+		fmt.Printf("%s %s %s (%s)\n", a.GetAddrMask(), verb, b.GetAddrMask(), a.Relation(b))
+	}
+	if !ok {
+		return exitNo
+	}
+	return exitYes
+}
@@ -7,65 +7,329 @@ import (
 	"goipcalc/pkg/ipcalc"
 	"goipcalc/pkg/output"
 	"os"
+	"strconv"
 	"strings"
 )
 
+// commands are subcommands selected by the first argument, each get
+// the rest of arguments and return exit status.
+var commands = map[string]func(args []string) int{
+	"split":     splitCMD,
+	"vlsm":      vlsmCMD,
+	"summarize": summarizeCMD,
+	"exclude":   excludeCMD,
+	"contains":  containsCMD,
+	"overlaps":  overlapsCMD,
+}
+
 func RootCMD() {
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	flag.Usage = func() {
 		fmt.Fprintln(os.Stderr, "Usage: goipcalc [OPTIONS] [ADDR/PLEN]")
+		fmt.Fprintln(os.Stderr, "       goipcalc split [OPTIONS] ADDR/PLEN /NEWPLEN")
+		fmt.Fprintln(os.Stderr, "       goipcalc vlsm [OPTIONS] ADDR/PLEN NAME=HOSTS...")
+		fmt.Fprintln(os.Stderr, "       goipcalc summarize [OPTIONS] ADDR/PLEN...")
+		fmt.Fprintln(os.Stderr, "       goipcalc exclude [OPTIONS] ADDR/PLEN EXCLUDE/PLEN...")
+		fmt.Fprintln(os.Stderr, "       goipcalc contains [OPTIONS] ADDR/PLEN ADDR|ADDR/PLEN...")
+		fmt.Fprintln(os.Stderr, "       goipcalc overlaps [OPTIONS] ADDR/PLEN ADDR/PLEN")
 		fmt.Fprintln(os.Stderr, "Examples:")
 		fmt.Fprintln(os.Stderr, "  goipcalc -d 10.0.0.1/24")
 		fmt.Fprintln(os.Stderr, "  goipcalc 2001:db8::1/64 192.168.10.11/28")
+		fmt.Fprintln(os.Stderr, "  goipcalc 10.0.0.1 255.255.255.0")
+		fmt.Fprintln(os.Stderr, "  goipcalc -w 10.1.0.0 0.0.255.255")
+		fmt.Fprintln(os.Stderr, "  goipcalc -c 172.16.4.1")
+		fmt.Fprintln(os.Stderr, "  goipcalc 10.0.0.5-10.0.0.200")
+		fmt.Fprintln(os.Stderr, "  goipcalc 192.0.2.1:8080 https://[2001:db8::1]:443/")
 		fmt.Fprintln(os.Stderr, "Options:")
 		fmt.Fprintln(os.Stderr, "  [ADDR/PLEN] address/prefix lenght, can be multiple")
+		fmt.Fprintln(os.Stderr, "              address without prefix is a host route")
+		fmt.Fprintln(os.Stderr, "              FIRST-LAST range is split to covering prefixes")
+		fmt.Fprintln(os.Stderr, "              in-addr.arpa and ip6.arpa names give covered prefix")
 		flag.PrintDefaults()
 	}
 
-	detail := flag.Bool("d", false, "IPv4 address to calculate")
-	jsonOut := flag.Bool("j", false, "json output")
-	jsonIndent := flag.Bool("json-indent", false, "change json output to indentation")
+	common := addCommonFlags(flag.CommandLine)
 
-	flag.Parse()
+	parseInterspersed(flag.CommandLine, os.Args[1:])
 
-	ips := flag.Args()
+	ips := joinMaskArgs(flag.Args(), common.isMask())
 	if len(ips) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: no address provided.")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	objList := make([]ipcalc.IP, 0, len(ips))
+	opts := common.opts()
+	objList := make([]output.Prettier, 0, len(ips))
 	var errors []string
 	if len(ips) > 0 {
 		for _, v := range ips {
-			if strings.Contains(v, ":") {
-				obj, err := ipcalc.ParseIPv6Prefix(v)
-				if err != nil {
-					errors = append(
-						errors,
-						fmt.Sprintf("skip %q: %v\n", v, err),
-					)
-					continue
-				}
-				objList = append(objList, obj)
-			} else {
-				obj, err := ipcalc.ParseIPv4Prefix(v)
-				if err != nil {
-					errors = append(
-						errors,
-						fmt.Sprintf("skip %q: %v\n", v, err),
-					)
-					continue
+			objs, norm, err := parseArg(v, *common.wildcard, opts)
+			if err != nil {
+				errors = append(
+					errors,
+					fmt.Sprintf("skip %q: %v\n", v, err),
+				)
+				continue
+			}
+			if norm != 0 && !*common.jsonOut {
+				fmt.Fprint(os.Stderr, normNote(v, norm))
+			}
+			for _, obj := range objs {
+				// JSON report normalization in every result
+				if ip, ok := obj.(ipcalc.IP); ok && norm != 0 {
+					obj = normalizedIP{IP: ip, norm: norm}
 				}
 				objList = append(objList, obj)
 			}
 		}
 	}
 
-	status, err := output.PrintOutput(*jsonOut, *jsonIndent, *detail, errors, objList)
+	status, err := output.PrintOutput(*common.jsonOut, *common.jsonIndent, common.format(), errors, objList)
 	if err != nil {
 		fmt.Println(err)
 	}
 	os.Exit(status)
 
 }
+
+// parseArg parse one argument of RootCMD. With wildcard, ACL style
+// "addr wildcard" argument (see isWildcardArg) is read as WildcardMatch,
+// or IP when the wildcard is contiguous. Normalization applied by
+// ipcalc.Parse is returned too.
+func parseArg(v string, wildcard bool, opts ipcalc.ParseOptions) ([]output.Prettier, ipcalc.Normalization, error) {
+	if wildcard && isWildcardArg(v) {
+		obj, err := ipcalc.ParseIPv4Wildcard(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		// contiguous wildcard is just a prefix
+		if ip, ok := obj.Prefix(); ok {
+			return []output.Prettier{ip}, 0, nil
+		}
+		return []output.Prettier{obj}, 0, nil
+	}
+
+	ips, norm, err := ipcalc.Parse(v, opts)
+	if err != nil {
+		return nil, norm, err
+	}
+	objs := make([]output.Prettier, 0, len(ips))
+	for _, ip := range ips {
+		objs = append(objs, ip)
+	}
+	return objs, norm, nil
+}
+
+// normalizedIP is IP parsed from normalized argument (port, brackets or
+// URL stripped), JSON output report it in "normalized" field.
+type normalizedIP struct {
+	ipcalc.IP
+	norm ipcalc.Normalization
+}
+
+// Normalization return normalization applied to the argument.
+func (n normalizedIP) Normalization() ipcalc.Normalization {
+	return n.norm
+}
+
+// normNote return note telling the user what was stripped from v.
+func normNote(v string, norm ipcalc.Normalization) string {
+	return fmt.Sprintf("note: %q: %s\n", v, norm)
+}
+
+// isMaskArg reports whether s, given as separate argument after an
+// address, is its mask. Many masks are valid addresses too ("10.0.0.1
+// 224.0.0.0" may be two addresses), so only masks of /8 and longer are
+// joined: dotted starting with 255 or hex with ff top byte. Shorter masks
+// must be written with the address (10.0.0.1/224.0.0.0).
+func isMaskArg(s string) bool {
+	if !ipcalc.IsIPv4Mask(s) {
+		return false
+	}
+	if strings.HasPrefix(s, "255.") {
+		return true
+	}
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return false
+	}
+	v, err := strconv.ParseUint(s[2:], 16, 32)
+	return err == nil && v>>24 == 0xff
+}
+
+// isMaskOrWildcard reports whether s is IPv4 mask (see isMaskArg) or
+// wildcard, used to join "addr mask" arguments with -w.
+func isMaskOrWildcard(s string) bool {
+	return isMaskArg(s) || ipcalc.IsIPv4Wildcard(s)
+}
+
+// isWildcardArg reports whether v is IPv4 "addr/wildcard" or
+// "addr wildcard" pair. Explicit pair given as one argument is always a
+// wildcard, unless it is a dotted mask, "addr/..." only when it looks
+// like inverse mask. /plen and bare address are not wildcards.
+func isWildcardArg(v string) bool {
+	if strings.Contains(v, ":") {
+		return false
+	}
+	if _, w, ok := strings.Cut(v, "/"); ok {
+		return ipcalc.IsIPv4Wildcard(w)
+	}
+	f := strings.Fields(v)
+	if len(f) != 2 {
+		return false
+	}
+	return ipcalc.IsIPv4Wildcard(f[1]) || !ipcalc.IsIPv4Mask(f[1])
+}
+
+// parseFlags hold flags changing how arguments are read, shared by
+// all commands.
+type parseFlags struct {
+	classful *bool
+	lenient  *bool
+	wildcard *bool
+	intIPv6  *bool
+}
+
+// addParseFlags define parse flags in fs.
+func addParseFlags(fs *flag.FlagSet) *parseFlags {
+	return &parseFlags{
+		classful: fs.Bool("c", false, "use classful prefix for IPv4 address without mask"),
+		lenient:  fs.Bool("lenient", false, "read IPv4 address with inet_aton rules (10.1, 0x0a.0.0.1, 012.0.0.1)"),
+		wildcard: fs.Bool("w", false, "read IPv4 mask as ACL wildcard (inverse) mask"),
+		intIPv6:  fs.Bool("int6", false, "read integer address as 128-bit IPv6, not 32-bit IPv4"),
+	}
+}
+
+// opts return parse options selected by flags.
+func (p *parseFlags) opts() ipcalc.ParseOptions {
+	return ipcalc.ParseOptions{Classful: *p.classful, Lenient: *p.lenient, IntIPv6: *p.intIPv6}
+}
+
+// isMask return function used by joinMaskArgs to find mask arguments.
+func (p *parseFlags) isMask() func(string) bool {
+	if *p.wildcard {
+		return isMaskOrWildcard
+	}
+	return isMaskArg
+}
+
+// parseIPs parse one argument to prefixes, like parseArg. Non-contiguous
+// wildcard is an error, as it is not a prefix.
+func (p *parseFlags) parseIPs(v string) ([]ipcalc.IP, ipcalc.Normalization, error) {
+	objs, norm, err := parseArg(v, *p.wildcard, p.opts())
+	if err != nil {
+		return nil, norm, err
+	}
+	ips := make([]ipcalc.IP, 0, len(objs))
+	for _, o := range objs {
+		ip, ok := o.(ipcalc.IP)
+		if !ok {
+			return nil, norm, fmt.Errorf("non-contiguous wildcard is not a prefix")
+		}
+		ips = append(ips, ip)
+	}
+	return ips, norm, nil
+}
+
+// parseOne parse argument which must give exactly one prefix.
+func (p *parseFlags) parseOne(v string) (ipcalc.IP, error) {
+	ips, _, err := p.parseIPs(v)
+	if err != nil {
+		return ipcalc.IP{}, err
+	}
+	if len(ips) != 1 {
+		return ipcalc.IP{}, fmt.Errorf("expected one prefix, got %d", len(ips))
+	}
+	return ips[0], nil
+}
+
+// parseArgs parse every argument (after joinMaskArgs) to prefixes.
+// Invalid arguments are skipped and reported in errors, like RootCMD do.
+// notes tell which arguments were normalized, see normNote.
+func (p *parseFlags) parseArgs(args []string) (ips []ipcalc.IP, notes, errors []string) {
+	for _, v := range joinMaskArgs(args, p.isMask()) {
+		objs, norm, err := p.parseIPs(v)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("skip %q: %v\n", v, err))
+			continue
+		}
+		if norm != 0 {
+			notes = append(notes, normNote(v, norm))
+		}
+		ips = append(ips, objs...)
+	}
+	return ips, notes, errors
+}
+
+// commonFlags hold parse and output flags shared by all commands.
+type commonFlags struct {
+	*parseFlags
+	detail     *bool
+	binary     *bool
+	jsonOut    *bool
+	jsonIndent *bool
+	expanded   *bool
+	mixed      *bool
+}
+
+// addCommonFlags define common flags in fs.
+func addCommonFlags(fs *flag.FlagSet) *commonFlags {
+	return &commonFlags{
+		parseFlags: addParseFlags(fs),
+		detail:     fs.Bool("d", false, "IPv4 address to calculate"),
+		binary:     fs.Bool("b", false, "show address, mask, network and last address in binary"),
+		jsonOut:    fs.Bool("j", false, "json output"),
+		jsonIndent: fs.Bool("json-indent", false, "change json output to indentation"),
+		expanded:   fs.Bool("e", false, "print IPv6 address expanded, all hextets with leading zeros"),
+		mixed:      fs.Bool("m", false, "print IPv6 address with dotted IPv4 tail (::ffff:192.0.2.1)"),
+	}
+}
+
+// format return output format selected by flags.
+func (c *commonFlags) format() ipcalc.Format {
+	return ipcalc.Format{Detail: *c.detail, Mixed: *c.mixed, Expanded: *c.expanded, Binary: *c.binary}
+}
+
+// parseInterspersed parse fs flags given anywhere in args, also after
+// positional arguments (split 2001:db8::/32 /48 -limit 0), which
+// fs.Parse alone leave unparsed. Arguments after "--" are positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) error {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			break
+		}
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			pos = append(pos, rest...)
+			break
+		}
+		pos = append(pos, rest[0])
+		args = rest[1:]
+	}
+	return fs.Parse(append([]string{"--"}, pos...))
+}
+
+// joinMaskArgs merge "<addr> <mask>" pair given as two arguments
+// (as pasted from ifconfig or device configs) into one argument.
+// isMask decide if the argument is a mask of the previous one.
+func joinMaskArgs(args []string, isMask func(string) bool) []string {
+	r := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		v := args[i]
+		if !strings.ContainsAny(v, "/:") && i+1 < len(args) && isMask(args[i+1]) {
+			v = v + " " + args[i+1]
+			i++
+		}
+		r = append(r, v)
+	}
+	return r
+}
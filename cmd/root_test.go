@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"flag"
+	"goipcalc/pkg/ipcalc"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var testCasesWildcardArgs = []struct {
+	args []string
+	exp  []string // Full address of every result
+}{
+	{[]string{"10.0.0.0/8"}, []string{"10.0.0.0/8"}},
+	{[]string{"10.0.0.1"}, []string{"10.0.0.1/32"}},
+	{[]string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.1/32", "10.0.0.2/32"}},
+	{[]string{"10.1.0.0", "0.0.255.255"}, []string{"10.1.0.0/16"}},
+	{[]string{"10.1.0.0/0.0.0.255"}, []string{"10.1.0.0/24"}},
+	{[]string{"10.0.0.0", "255.255.255.0"}, []string{"10.0.0.0/24"}},
+	{[]string{"10.0.0.0/255.255.0.0"}, []string{"10.0.0.0/16"}},
+	{[]string{"10.0.0.1", "0.0.255.0", "10.0.0.0/8"}, []string{"10.0.0.1 0.0.255.0", "10.0.0.0/8"}},
+	// explicit pair in one argument
+	{[]string{"10.0.0.1 0.255.0.255"}, []string{"10.0.0.1 0.255.0.255"}},
+	{[]string{"2001:db8::1/64"}, []string{"2001:db8::1/64"}},
+}
+
+func TestParseArgWildcard(t *testing.T) {
+	for _, tt := range testCasesWildcardArgs {
+		var got []string
+		for _, v := range joinMaskArgs(tt.args, isMaskOrWildcard) {
+			objs, _, err := parseArg(v, true, ipcalc.ParseOptions{})
+			if err != nil {
+				t.Errorf("%q unexpected error: %v", v, err)
+				continue
+			}
+			for _, o := range objs {
+				got = append(got, o.Pretty(ipcalc.Format{})[0][1])
+			}
+		}
+		if !slices.Equal(got, tt.exp) {
+			t.Errorf("%q got %q, want %q", tt.args, got, tt.exp)
+		}
+	}
+}
+
+func TestParseArgsNotes(t *testing.T) {
+	f := false
+	p := &parseFlags{classful: &f, lenient: &f, wildcard: &f, intIPv6: &f}
+	ips, notes, errors := p.parseArgs([]string{"192.0.2.1:80", "10.0.0.0/8", "[2001:db8::1]:443"})
+	if len(ips) != 3 || len(errors) != 0 {
+		t.Fatalf("got %d prefixes, errors %q", len(ips), errors)
+	}
+	exp := []string{
+		"note: \"192.0.2.1:80\": stripped port\n",
+		"note: \"[2001:db8::1]:443\": stripped brackets, stripped port\n",
+	}
+	if !slices.Equal(notes, exp) {
+		t.Errorf("got %q, want %q", notes, exp)
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expLimit uint64
+		expQuiet bool
+		expArgs  []string
+	}{
+		{[]string{"-limit", "5", "2001:db8::/32", "/48"}, 5, false, []string{"2001:db8::/32", "/48"}},
+		{[]string{"2001:db8::/32", "/48", "-limit", "0"}, 0, false, []string{"2001:db8::/32", "/48"}},
+		{[]string{"10.0.0.0/16", "-q", "/24", "-limit=7"}, 7, true, []string{"10.0.0.0/16", "/24"}},
+		{[]string{"10.0.0.0/16", "--", "-q"}, 1, false, []string{"10.0.0.0/16", "-q"}},
+		{nil, 1, false, nil},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		limit := fs.Uint64("limit", 1, "")
+		quiet := fs.Bool("q", false, "")
+		if err := parseInterspersed(fs, tt.args); err != nil {
+			t.Errorf("%q unexpected error: %v", tt.args, err)
+			continue
+		}
+		if *limit != tt.expLimit || *quiet != tt.expQuiet || !slices.Equal(fs.Args(), tt.expArgs) {
+			t.Errorf("%q got limit %d, quiet %v, args %q", tt.args, *limit, *quiet, fs.Args())
+		}
+	}
+}
+
+// runCMD run command with args and return its stdout and exit status.
+// Stderr is discarded.
+func runCMD(t *testing.T, run func([]string) int, args ...string) (string, int) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Close()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, null
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+	status := run(args)
+	w.Close()
+	return <-out, status
+}
+
+func TestExcludeDottedMaskParent(t *testing.T) {
+	out, status := runCMD(t, excludeCMD, "-j", "10.0.0.0", "255.0.0.0", "10.1.2.0", "255.255.255.0")
+	if status != 0 {
+		t.Fatalf("status got %d, want 0", status)
+	}
+	// 10.0.0.0/8 without 10.1.2.0/24 is 16 prefixes, /32 parent give none
+	if n := strings.Count(out, "full_address"); n != 16 {
+		t.Errorf("got %d prefixes, want 16: %s", n, out)
+	}
+	if !strings.Contains(out, `"10.0.0.0/16"`) || !strings.Contains(out, `"10.128.0.0/9"`) {
+		t.Errorf("missing 10.0.0.0/16 or 10.128.0.0/9: %s", out)
+	}
+}
+
+func TestSplitDottedMaskParent(t *testing.T) {
+	out, status := runCMD(t, splitCMD, "-j", "10.0.0.0", "255.255.255.0", "/26")
+	if status != 0 {
+		t.Fatalf("status got %d, want 0", status)
+	}
+	if n := strings.Count(out, "full_address"); n != 4 || !strings.Contains(out, `"10.0.0.192/26"`) {
+		t.Errorf("want 4 subnets up to 10.0.0.192/26, got %s", out)
+	}
+}
+
+func TestVLSMDottedMaskParent(t *testing.T) {
+	out, status := runCMD(t, vlsmCMD, "-j", "192.168.1.0", "255.255.255.0", "a=10")
+	if status != 0 {
+		t.Fatalf("status got %d, want 0", status)
+	}
+	if !strings.Contains(out, `"name":"a"`) || !strings.Contains(out, `"192.168.1.0/28"`) {
+		t.Errorf("want a in 192.168.1.0/28, got %s", out)
+	}
+}
+
+func TestQueryStatus(t *testing.T) {
+	tests := []struct {
+		run    func([]string) int
+		args   []string
+		exp    int
+		expOut bool
+	}{
+		{containsCMD, []string{"10.0.0.0/8", "10.1.2.3", "10.200.0.0/16"}, exitYes, true},
+		{containsCMD, []string{"10.0.0.0/8", "10.1.2.3", "11.0.0.1"}, exitNo, true},
+		{containsCMD, []string{"-q", "10.0.0.0/8", "11.0.0.1"}, exitNo, false},
+		{containsCMD, []string{"10.0.0.0/8", "-q", "10.1.2.3"}, exitYes, false},
+		{containsCMD, []string{"-q", "fe80::%eth0/64", "fe80::1%eth1"}, exitNo, false},
+		{containsCMD, []string{"10.0.0.0", "255.0.0.0", "10.1.2.3"}, exitYes, true},
+		{containsCMD, []string{"10.0.0.0/8", "bogus"}, exitError, false},
+		{containsCMD, []string{"10.0.0.0/8"}, exitError, false},
+		{overlapsCMD, []string{"10.0.0.0/24", "10.0.0.128/25"}, exitYes, true},
+		{overlapsCMD, []string{"-q", "10.0.0.0/24", "10.0.1.0/24"}, exitNo, false},
+		{overlapsCMD, []string{"10.0.0.0/24", "10.0.1.0/24"}, exitNo, true},
+		{overlapsCMD, []string{"10.0.0.0/24", "bogus"}, exitError, false},
+		{overlapsCMD, []string{"10.0.0.0/24"}, exitError, false},
+	}
+	for _, tt := range tests {
+		out, status := runCMD(t, tt.run, tt.args...)
+		if status != tt.exp {
+			t.Errorf("%q status got %d, want %d", tt.args, status, tt.exp)
+		}
+		if (out != "") != tt.expOut {
+			t.Errorf("%q output got %q, want output %v", tt.args, out, tt.expOut)
+		}
+	}
+}
+
+func TestExcludeEverything(t *testing.T) {
+	out, status := runCMD(t, excludeCMD, "10.0.0.0/24", "10.0.0.0/23")
+	if status != 0 || out != "" {
+		t.Errorf("CLI got status %d, output %q", status, out)
+	}
+	out, status = runCMD(t, excludeCMD, "-j", "10.0.0.0/24", "10.0.0.0/23")
+	if status != 0 || out != "{\"results\":[]}\n" {
+		t.Errorf("JSON got status %d, output %q", status, out)
+	}
+}
+
+var testCasesJoinMaskArgs = []struct {
+	args []string
+	exp  []string
+}{
+	{[]string{"10.0.0.1", "255.255.255.0"}, []string{"10.0.0.1 255.255.255.0"}},
+	{[]string{"10.0.0.1", "255.0.0.0", "10.0.0.2"}, []string{"10.0.0.1 255.0.0.0", "10.0.0.2"}},
+	{[]string{"10.0.0.1", "0xffffff00"}, []string{"10.0.0.1 0xffffff00"}},
+	{[]string{"10.0.0.1/8", "255.255.255.0"}, []string{"10.0.0.1/8", "255.255.255.0"}},
+	// two bare addresses, second one looks like a short mask
+	{[]string{"10.0.0.1", "0.0.0.0"}, []string{"10.0.0.1", "0.0.0.0"}},
+	{[]string{"10.0.0.1", "224.0.0.0"}, []string{"10.0.0.1", "224.0.0.0"}},
+	{[]string{"10.0.0.1", "128.0.0.0"}, []string{"10.0.0.1", "128.0.0.0"}},
+	{[]string{"192.168.1.1", "0xC0000000"}, []string{"192.168.1.1", "0xC0000000"}},
+	{[]string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.1", "10.0.0.2"}},
+	// not contiguous
+	{[]string{"10.0.0.1", "255.0.255.0"}, []string{"10.0.0.1", "255.0.255.0"}},
+}
+
+func TestJoinMaskArgs(t *testing.T) {
+	for _, tt := range testCasesJoinMaskArgs {
+		if got := joinMaskArgs(tt.args, isMaskArg); !slices.Equal(got, tt.exp) {
+			t.Errorf("%q got %q, want %q", tt.args, got, tt.exp)
+		}
+	}
+}
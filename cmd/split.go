@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"goipcalc/pkg/output"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// splitCMD handle "goipcalc split ADDR/PLEN /NEWPLEN", listing every
+// subnet of ADDR/PLEN with NEWPLEN prefix len.
+func splitCMD(args []string) int {
+	fs := flag.NewFlagSet("split", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: goipcalc split [OPTIONS] ADDR/PLEN /NEWPLEN")
+		fmt.Fprintln(os.Stderr, "Examples:")
+		fmt.Fprintln(os.Stderr, "  goipcalc split 10.0.0.0/16 /24")
+		fmt.Fprintln(os.Stderr, "  goipcalc split -offset 65280 -limit 16 2001:db8::/32 /48")
+		fmt.Fprintln(os.Stderr, "Options:")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	limit := fs.Uint64("limit", 65536, "print at most this many subnets, 0 for all")
+	offset := fs.String("offset", "0", "skip this many subnets first")
+	parseInterspersed(fs, args)
+
+	sargs := joinMaskArgs(fs.Args(), common.isMask())
+	if len(sargs) != 2 {
+		fmt.Fprintln(os.Stderr, "Error: expected ADDR/PLEN and /NEWPLEN.")
+		fs.Usage()
+		return 1
+	}
+
+	ip, err := common.parseOne(sargs[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %q: %v\n", sargs[0], err)
+		return 1
+	}
+	pfx, err := strconv.ParseUint(strings.TrimPrefix(sargs[1], "/"), 10, 8)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid prefix len %q\n", sargs[1])
+		return 1
+	}
+	off, ok := new(big.Int).SetString(*offset, 10)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: invalid offset %q\n", *offset)
+		return 1
+	}
+
+	subnets, err := ipcalc.Split(ip, uint8(pfx), off)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// tell the user when output is cut by the limit
+	count, _ := ipcalc.SplitCount(ip, uint8(pfx))
+	left := new(big.Int).Sub(count, off)
+	if *limit > 0 && left.Cmp(new(big.Int).SetUint64(*limit)) > 0 {
+		next := new(big.Int).Add(off, new(big.Int).SetUint64(*limit))
+		fmt.Fprintf(os.Stderr, "showing %d of %s subnets, use -offset %s for more\n", *limit, count, next)
+	}
+
+	seq := func(yield func(output.Prettier) bool) {
+		var n uint64
+		for sub := range subnets {
+			if *limit > 0 && n == *limit {
+				return
+			}
+			n++
+			if !yield(sub) {
+				return
+			}
+		}
+	}
+	if err := output.StreamOutput(os.Stdout, *common.jsonOut, *common.jsonIndent, common.format(), seq); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"goipcalc/pkg/output"
+	"os"
+	"strings"
+)
+
+// summarizeCMD handle "goipcalc summarize ADDR/PLEN...", printing the
+// minimal list of prefixes covering all arguments.
+func summarizeCMD(args []string) int {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: goipcalc summarize [OPTIONS] ADDR/PLEN...")
+		fmt.Fprintln(os.Stderr, "Examples:")
+		fmt.Fprintln(os.Stderr, "  goipcalc summarize 10.0.0.0/24 10.0.1.0/24 10.0.2.0/23")
+		fmt.Fprintln(os.Stderr, "  goipcalc summarize 2001:db8::/48 2001:db8:1::/48 192.0.2.0/25 192.0.2.128/25")
+		fmt.Fprintln(os.Stderr, "Options:")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	parseInterspersed(fs, args)
+
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no address provided.")
+		fs.Usage()
+		return 1
+	}
+
+	ips, notes, errors := common.parseArgs(fs.Args())
+	if !*common.jsonOut {
+		fmt.Fprint(os.Stderr, strings.Join(notes, ""))
+	}
+	objList := []output.Prettier{}
+	for _, ip := range ipcalc.Summarize(ips) {
+		objList = append(objList, ip)
+	}
+	status, err := output.PrintOutput(*common.jsonOut, *common.jsonIndent, common.format(), errors, objList)
+	if err != nil {
+		fmt.Println(err)
+	}
+	return status
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"goipcalc/pkg/output"
+	"os"
+)
+
+// vlsmCMD handle "goipcalc vlsm PARENT NAME=HOSTS...", allocating
+// subnets of PARENT for every demand.
+func vlsmCMD(args []string) int {
+	fs := flag.NewFlagSet("vlsm", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: goipcalc vlsm [OPTIONS] ADDR/PLEN NAME=HOSTS...")
+		fmt.Fprintln(os.Stderr, "Examples:")
+		fmt.Fprintln(os.Stderr, "  goipcalc vlsm 192.168.1.0/24 office=120 voip=50 mgmt=10 p2p=2")
+		fmt.Fprintln(os.Stderr, "Options:")
+		fs.PrintDefaults()
+	}
+	common := addCommonFlags(fs)
+	parseInterspersed(fs, args)
+
+	vargs := joinMaskArgs(fs.Args(), common.isMask())
+	if len(vargs) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: expected ADDR/PLEN and at least one NAME=HOSTS.")
+		fs.Usage()
+		return 1
+	}
+
+	parent, err := common.parseOne(vargs[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %q: %v\n", vargs[0], err)
+		return 1
+	}
+	demands := make([]ipcalc.Demand, 0, len(vargs)-1)
+	for _, v := range vargs[1:] {
+		d, err := ipcalc.ParseDemand(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		demands = append(demands, d)
+	}
+
+	plan, err := ipcalc.VLSM(parent, demands)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	objList := make([]output.Prettier, 0, len(plan.Allocations)+len(plan.Free))
+	for _, a := range plan.Allocations {
+		objList = append(objList, a)
+	}
+	for _, ip := range plan.Free {
+		objList = append(objList, ipcalc.Allocation{IP: ip})
+	}
+	status, err := output.PrintOutput(*common.jsonOut, *common.jsonIndent, common.format(), nil, objList)
+	if err != nil {
+		fmt.Println(err)
+	}
+	return status
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"strings"
+)
+
+// BinaryMarker separate network and host bits in BinaryAddr output.
+const BinaryMarker = "|"
+
+// BinaryAddr format address as binary string split with '.' per octet
+// (IPv4) or ':' per hextet (IPv6), with BinaryMarker placed on the prefix
+// boundary, e.g. 11000000.10101000.00000001.00|000001 for /26.
+func BinaryAddr(ip []uint16, pfx uint8) string {
+	return binaryRow(ip, pfx, func(bit uint16, _ int) byte {
+		return '0' + byte(bit)
+	})
+}
+
+// BinaryRuler return ruler aligned with BinaryAddr output, where 'n'
+// is a network bit and 'h' is a host bit.
+func BinaryRuler(ip IP) string {
+	return binaryRow(ip.Addr, ip.Pfx, func(_ uint16, i int) byte {
+		if i < int(ip.Pfx) {
+			return 'n'
+		}
+		return 'h'
+	})
+}
+
+// binaryRow build binary view of the address, char return the character
+// for bit value and bit index.
+func binaryRow(ip []uint16, pfx uint8, char func(bit uint16, i int) byte) string {
+	group, sep := 16, byte(':')
+	if len(ip) == 2 {
+		group, sep = 8, '.'
+	}
+	total := len(ip) * 16
+
+	var b strings.Builder
+	for i := range total {
+		if i > 0 && i%group == 0 {
+			b.WriteByte(sep)
+		}
+		if i == int(pfx) {
+			b.WriteString(BinaryMarker)
+		}
+		bit := (ip[i/16] >> (15 - i%16)) & 1
+		b.WriteByte(char(bit, i))
+	}
+	if int(pfx) == total {
+		b.WriteString(BinaryMarker)
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesBinary = []struct {
+	input    string
+	expAddr  string
+	expRuler string
+}{
+	{
+		"192.168.1.1/26",
+		"11000000.10101000.00000001.00|000001",
+		"nnnnnnnn.nnnnnnnn.nnnnnnnn.nn|hhhhhh",
+	},
+	{
+		"192.168.1.1/24",
+		"11000000.10101000.00000001.|00000001",
+		"nnnnnnnn.nnnnnnnn.nnnnnnnn.|hhhhhhhh",
+	},
+	{
+		"10.0.0.1/0",
+		"|00001010.00000000.00000000.00000001",
+		"|hhhhhhhh.hhhhhhhh.hhhhhhhh.hhhhhhhh",
+	},
+	{
+		"10.0.0.1/32",
+		"00001010.00000000.00000000.00000001|",
+		"nnnnnnnn.nnnnnnnn.nnnnnnnn.nnnnnnnn|",
+	},
+	{
+		"2001:db8::1/20",
+		"0010000000000001:0000|110110111000:" +
+			"0000000000000000:0000000000000000:0000000000000000:" +
+			"0000000000000000:0000000000000000:0000000000000001",
+		"nnnnnnnnnnnnnnnn:nnnn|hhhhhhhhhhhh:" +
+			"hhhhhhhhhhhhhhhh:hhhhhhhhhhhhhhhh:hhhhhhhhhhhhhhhh:" +
+			"hhhhhhhhhhhhhhhh:hhhhhhhhhhhhhhhh:hhhhhhhhhhhhhhhh",
+	},
+}
+
+func TestBinaryAddr(t *testing.T) {
+	for _, tt := range testCasesBinary {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		ip := ips[0]
+		if r := ipcalc.BinaryAddr(ip.Addr, ip.Pfx); r != tt.expAddr {
+			t.Errorf("%q binary got %v, want %v", tt.input, r, tt.expAddr)
+		}
+		if r := ipcalc.BinaryRuler(ip); r != tt.expRuler {
+			t.Errorf("%q ruler got %v, want %v", tt.input, r, tt.expRuler)
+		}
+	}
+}
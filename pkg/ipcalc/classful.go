@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+// Relation of the prefix to its classful network.
+const (
+	StatusClassful    = "classful"
+	StatusSubnetted   = "subnetted"
+	StatusSupernetted = "supernetted"
+)
+
+// ClassInfo describe IPv4 address in the old classful addressing
+// (RFC 791). Class D and E have no default mask, so Network is nil and
+// Status is empty for them.
+type ClassInfo struct {
+	// Class is A, B, C, D or E.
+	Class string
+	// Network is classful network of the address with default mask.
+	Network *IP
+	// Status tells if prefix is classful, subnetted or supernetted.
+	Status string
+}
+
+// ClassfulInfo return classful information of the IPv4 address. The
+// second value is false for IPv6.
+func ClassfulInfo(ip IP) (ClassInfo, bool) {
+	var c ClassInfo
+	if len(ip.Addr) != 2 {
+		return c, false
+	}
+
+	first := byte(ip.Addr[0] >> 8)
+	switch {
+	case first < 128:
+		c.Class = "A"
+	case first < 192:
+		c.Class = "B"
+	case first < 224:
+		c.Class = "C"
+	case first < 240:
+		c.Class = "D"
+	default:
+		c.Class = "E"
+	}
+
+	pfx, ok := classfulPrefix(ip.Addr)
+	if !ok {
+		return c, true
+	}
+	network := newIP(ip.Addr, pfx)
+	network.Addr = network.GetFirstAddr()
+	c.Network = &network
+
+	switch {
+	case ip.Pfx > pfx:
+		c.Status = StatusSubnetted
+	case ip.Pfx < pfx:
+		c.Status = StatusSupernetted
+	default:
+		c.Status = StatusClassful
+	}
+	return c, true
+}
+
+// rows return detail rows of the classful information.
+func (c ClassInfo) rows(f Format) [][2]string {
+	result := [][2]string{{"Class", c.Class}}
+	if c.Network != nil {
+		result = append(result,
+			[2]string{"Classful mask", f.Addr(c.Network.Mask)},
+			[2]string{"Classful network", f.AddrMask(*c.Network)},
+			[2]string{"Classful status", c.Status},
+		)
+	}
+	return result
+}
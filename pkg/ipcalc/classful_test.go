@@ -0,0 +1,55 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesClassful = []struct {
+	input      string
+	expClass   string
+	expNetwork string
+	expStatus  string
+	ok         bool
+}{
+	{"10.1.2.3/8", "A", "10.0.0.0/8", ipcalc.StatusClassful, true},
+	{"10.1.2.3/16", "A", "10.0.0.0/8", ipcalc.StatusSubnetted, true},
+	{"0.0.0.0/0", "A", "0.0.0.0/8", ipcalc.StatusSupernetted, true},
+	{"127.0.0.1/32", "A", "127.0.0.0/8", ipcalc.StatusSubnetted, true},
+	{"128.0.0.1/16", "B", "128.0.0.0/16", ipcalc.StatusClassful, true},
+	{"172.16.0.0/12", "B", "172.16.0.0/16", ipcalc.StatusSupernetted, true},
+	{"191.255.1.1/24", "B", "191.255.0.0/16", ipcalc.StatusSubnetted, true},
+	{"192.168.1.0/24", "C", "192.168.1.0/24", ipcalc.StatusClassful, true},
+	{"192.168.0.0/16", "C", "192.168.0.0/24", ipcalc.StatusSupernetted, true},
+	{"223.255.255.1/30", "C", "223.255.255.0/24", ipcalc.StatusSubnetted, true},
+	{"224.0.0.1/32", "D", "", "", true},
+	{"239.255.255.255/8", "D", "", "", true},
+	{"240.0.0.1/4", "E", "", "", true},
+	{"255.255.255.255/32", "E", "", "", true},
+
+	{"2001:db8::1/64", "", "", "", false},
+}
+
+func TestClassfulInfo(t *testing.T) {
+	for _, tt := range testCasesClassful {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		c, ok := ipcalc.ClassfulInfo(ips[0])
+		if ok != tt.ok {
+			t.Errorf("%q ok got %v, want %v", tt.input, ok, tt.ok)
+			continue
+		}
+
+		var network string
+		if c.Network != nil {
+			network = c.Network.GetAddrMask()
+		}
+		if c.Class != tt.expClass || network != tt.expNetwork || c.Status != tt.expStatus {
+			t.Errorf("%q got %q %q %q, want %q %q %q", tt.input,
+				c.Class, network, c.Status, tt.expClass, tt.expNetwork, tt.expStatus)
+		}
+	}
+}
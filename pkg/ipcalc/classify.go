@@ -0,0 +1,283 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Categories returned by Classify.
+const (
+	CategoryPrivate       = "private"
+	CategoryCGNAT         = "cgnat"
+	CategoryLoopback      = "loopback"
+	CategoryLinkLocal     = "link-local"
+	CategoryDocumentation = "documentation"
+	CategoryBenchmarking  = "benchmarking"
+	CategoryULA           = "ula"
+	CategoryMulticast     = "multicast"
+	Category6to4          = "6to4"
+	CategoryTeredo        = "teredo"
+	CategoryReserved      = "reserved"
+	CategoryGlobalUnicast = "global unicast"
+	// CategoryMixed is used for prefix which overlaps special-purpose
+	// block but is not contained in any.
+	CategoryMixed = "mixed"
+)
+
+// Copies of IANA IPv4 and IPv6 Special-Purpose Address Registries
+// (https://www.iana.org/assignments/iana-ipv4-special-registry,
+// https://www.iana.org/assignments/iana-ipv6-special-registry).
+var (
+	//go:embed data/iana-ipv4-special-registry.csv
+	ipv4SpecialCSV string
+	//go:embed data/iana-ipv6-special-registry.csv
+	ipv6SpecialCSV string
+)
+
+// Class describe address block the IP belongs to.
+type Class struct {
+	// Category is one of Category* constants.
+	Category string
+	// Name of the block in the registry, e.g. Private-Use.
+	Name string
+	// Block is the matched registry block, e.g. 10.0.0.0/8.
+	Block string
+	// RFC defining the block, e.g. RFC1918.
+	RFC string
+	// Forwardable and GloballyReachable are registry flags. Blocks with
+	// N/A in the registry are reported as false.
+	Forwardable       bool
+	GloballyReachable bool
+	// ReachabilityUnknown is set when global reachability depends on the
+	// group, as for IPv4 multicast (224.0.0.0/24 is link-local, GLOP
+	// 233.0.0.0/8 is routed globally). GloballyReachable is false then.
+	ReachabilityUnknown bool
+}
+
+// String return one line description, e.g.
+// "private (Private-Use, 10.0.0.0/8, RFC1918), forwardable, not globally reachable".
+func (c Class) String() string {
+	var b strings.Builder
+	b.WriteString(c.Category)
+
+	var details []string
+	for _, d := range []string{c.Name, c.Block, c.RFC} {
+		if d != "" {
+			details = append(details, d)
+		}
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	if c.Category == CategoryMixed {
+		return b.String()
+	}
+	if !c.Forwardable {
+		b.WriteString(", not")
+	} else {
+		b.WriteString(",")
+	}
+	b.WriteString(" forwardable")
+	if c.ReachabilityUnknown {
+		return b.String()
+	}
+	if !c.GloballyReachable {
+		b.WriteString(", not")
+	} else {
+		b.WriteString(",")
+	}
+	b.WriteString(" globally reachable")
+	return b.String()
+}
+
+// specialBlock is single parsed registry entry.
+type specialBlock struct {
+	pfx    uint8
+	lo, hi *big.Int
+	class  Class
+}
+
+var (
+	specialOnce sync.Once
+	specialIPv4 []specialBlock
+	specialIPv6 []specialBlock
+)
+
+// Classify return Classify(ip), so types embedding IP (like Allocation)
+// can be classified too.
+func (ip IP) Classify() Class {
+	return Classify(ip)
+}
+
+// Classify return category and registry flags of the ip. Prefix is matched
+// with the most specific registry block containing the whole prefix.
+func Classify(ip IP) Class {
+	specialOnce.Do(loadSpecial)
+
+	blocks, n := specialIPv4, len(ip.Addr)
+	if n == 8 {
+		blocks = specialIPv6
+	}
+
+	lo, hi := addrToBig(ip.GetFirstAddr()), addrToBig(ip.GetLastAddr())
+	best := -1
+	overlap := false
+	for i, b := range blocks {
+		switch {
+		case b.lo.Cmp(lo) <= 0 && hi.Cmp(b.hi) <= 0:
+			if best == -1 || b.pfx > blocks[best].pfx {
+				best = i
+			}
+		case b.lo.Cmp(hi) <= 0 && lo.Cmp(b.hi) <= 0:
+			overlap = true
+		}
+	}
+
+	switch {
+	case best != -1:
+		c := blocks[best].class
+		if c.Category == CategoryMulticast && n == 8 {
+			return multicastScopeReach(ip, c)
+		}
+		return c
+	case overlap:
+		return Class{Category: CategoryMixed, Name: "overlaps special-purpose blocks"}
+	}
+
+	// outside of 2000::/3 IPv6 space is reserved by IETF
+	if n == 8 && (ip.Pfx < 3 || ip.Addr[0]>>13 != 1) {
+		return Class{Category: CategoryReserved, Name: "Reserved by IETF", RFC: "RFC4291"}
+	}
+	return Class{
+		Category:          CategoryGlobalUnicast,
+		Name:              "Global Unicast",
+		Forwardable:       true,
+		GloballyReachable: true,
+	}
+}
+
+// multicastScopeReach set global reachability of IPv6 multicast from its
+// scope (RFC 7346): only global scope (ff0e::) groups are reachable.
+// Prefix shorter than /16 has no single scope, so it stay unknown.
+func multicastScopeReach(ip IP, c Class) Class {
+	if ip.Pfx < 16 {
+		return c
+	}
+	c.ReachabilityUnknown = false
+	c.GloballyReachable = ip.Addr[0]&0xf == 0xe
+	return c
+}
+
+// loadSpecial parse embedded registries. Multicast blocks are not in the
+// registries, their reachability is left unknown.
+func loadSpecial() {
+	specialIPv4 = parseSpecialCSV(ipv4SpecialCSV)
+	specialIPv4 = append(specialIPv4, newSpecialBlock("224.0.0.0/4", Class{
+		Category:            CategoryMulticast,
+		Name:                "Multicast",
+		RFC:                 "RFC5771",
+		Forwardable:         true,
+		ReachabilityUnknown: true,
+	}))
+	specialIPv6 = parseSpecialCSV(ipv6SpecialCSV)
+	specialIPv6 = append(specialIPv6, newSpecialBlock("ff00::/8", Class{
+		Category:            CategoryMulticast,
+		Name:                "Multicast",
+		RFC:                 "RFC4291",
+		Forwardable:         true,
+		ReachabilityUnknown: true,
+	}))
+}
+
+var (
+	footnoteRe = regexp.MustCompile(`\s*\[\d+\]`)
+	rfcRe      = regexp.MustCompile(`RFC\s?\d+`)
+)
+
+// parseSpecialCSV parse IANA registry in CSV format. Lines with missing
+// columns are skipped.
+func parseSpecialCSV(data string) []specialBlock {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("ipcalc: broken embedded registry: %v", err))
+	}
+
+	var r []specialBlock
+	for _, rec := range records[1:] {
+		if len(rec) < 10 {
+			continue
+		}
+		for i := range rec {
+			rec[i] = strings.TrimSpace(footnoteRe.ReplaceAllString(rec[i], ""))
+		}
+
+		c := Class{
+			Name:              strings.Trim(rec[1], `"`),
+			RFC:               strings.Join(rfcRe.FindAllString(rec[2], -1), ", "),
+			Forwardable:       rec[7] == "True",
+			GloballyReachable: rec[8] == "True",
+		}
+		c.Category = specialCategory(c)
+
+		for _, block := range strings.Split(rec[0], ",") {
+			r = append(r, newSpecialBlock(strings.TrimSpace(block), c))
+		}
+	}
+	return r
+}
+
+// newSpecialBlock build registry entry of the block. The block comes
+// from embedded data, so parsing can't fail.
+func newSpecialBlock(block string, c Class) specialBlock {
+	ips, _, err := Parse(block, ParseOptions{})
+	if err != nil {
+		panic(fmt.Sprintf("ipcalc: broken embedded registry block %q: %v", block, err))
+	}
+	ip := ips[0]
+	c.Block = block
+	return specialBlock{
+		pfx:   ip.Pfx,
+		lo:    addrToBig(ip.GetFirstAddr()),
+		hi:    addrToBig(ip.GetLastAddr()),
+		class: c,
+	}
+}
+
+// specialCategory map registry name to category.
+func specialCategory(c Class) string {
+	name := strings.ToLower(c.Name)
+	switch {
+	case strings.Contains(name, "private-use"):
+		return CategoryPrivate
+	case strings.Contains(name, "shared address space"):
+		return CategoryCGNAT
+	case strings.Contains(name, "loopback"):
+		return CategoryLoopback
+	case strings.Contains(name, "link local"), strings.Contains(name, "link-local"):
+		return CategoryLinkLocal
+	case strings.Contains(name, "documentation"):
+		return CategoryDocumentation
+	case strings.Contains(name, "benchmarking"):
+		return CategoryBenchmarking
+	case strings.Contains(name, "unique-local"):
+		return CategoryULA
+	case strings.Contains(name, "6to4"):
+		return Category6to4
+	case strings.Contains(name, "teredo"):
+		return CategoryTeredo
+	case c.GloballyReachable:
+		return CategoryGlobalUnicast
+	default:
+		return CategoryReserved
+	}
+}
@@ -0,0 +1,115 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesClassify = []struct {
+	input       string
+	expCategory string
+	expBlock    string
+	expFwd      bool
+	expGlobal   bool
+}{
+	// IPv4
+	{"10.1.2.3/24", ipcalc.CategoryPrivate, "10.0.0.0/8", true, false},
+	{"172.16.0.1", ipcalc.CategoryPrivate, "172.16.0.0/12", true, false},
+	{"192.168.1.1/16", ipcalc.CategoryPrivate, "192.168.0.0/16", true, false},
+	{"100.64.1.1/24", ipcalc.CategoryCGNAT, "100.64.0.0/10", true, false},
+	{"127.0.0.1/8", ipcalc.CategoryLoopback, "127.0.0.0/8", false, false},
+	{"169.254.10.1/16", ipcalc.CategoryLinkLocal, "169.254.0.0/16", false, false},
+	{"192.0.2.1/24", ipcalc.CategoryDocumentation, "192.0.2.0/24", false, false},
+	{"198.51.100.7", ipcalc.CategoryDocumentation, "198.51.100.0/24", false, false},
+	{"203.0.113.1", ipcalc.CategoryDocumentation, "203.0.113.0/24", false, false},
+	{"198.19.0.1/16", ipcalc.CategoryBenchmarking, "198.18.0.0/15", true, false},
+	{"224.0.0.5", ipcalc.CategoryMulticast, "224.0.0.0/4", true, false},
+	{"240.0.0.1", ipcalc.CategoryReserved, "240.0.0.0/4", false, false},
+	{"192.88.99.1", ipcalc.Category6to4, "192.88.99.0/24", false, false},
+	{"192.0.0.170", ipcalc.CategoryReserved, "192.0.0.170/32", false, false},
+	{"192.0.0.171", ipcalc.CategoryReserved, "192.0.0.171/32", false, false},
+	{"192.0.0.9", ipcalc.CategoryGlobalUnicast, "192.0.0.9/32", true, true},
+	{"0.0.0.0", ipcalc.CategoryReserved, "0.0.0.0/32", false, false},
+	{"255.255.255.255", ipcalc.CategoryReserved, "255.255.255.255/32", false, false},
+	{"8.8.8.8/24", ipcalc.CategoryGlobalUnicast, "", true, true},
+	{"10.0.0.0/7", ipcalc.CategoryMixed, "", false, false},
+
+	// IPv6
+	{"::1", ipcalc.CategoryLoopback, "::1/128", false, false},
+	{"fd00::1/64", ipcalc.CategoryULA, "fc00::/7", true, false},
+	{"fe80::1%eth0/64", ipcalc.CategoryLinkLocal, "fe80::/10", false, false},
+	{"2001:db8::1/64", ipcalc.CategoryDocumentation, "2001:db8::/32", false, false},
+	{"3fff::1/64", ipcalc.CategoryDocumentation, "3fff::/20", false, false},
+	{"2001:2::1/64", ipcalc.CategoryBenchmarking, "2001:2::/48", true, false},
+	{"ff02::1", ipcalc.CategoryMulticast, "ff00::/8", true, false},
+	{"2002:c000:201::1/48", ipcalc.Category6to4, "2002::/16", true, false},
+	{"2001:0:4136:e378::1/64", ipcalc.CategoryTeredo, "2001::/32", true, false},
+	{"2001:1::1", ipcalc.CategoryGlobalUnicast, "2001:1::1/128", true, true},
+	{"::ffff:192.0.2.1", ipcalc.CategoryReserved, "::ffff:0:0/96", false, false},
+	{"2a00:1450::1/32", ipcalc.CategoryGlobalUnicast, "", true, true},
+	{"4000::1/64", ipcalc.CategoryReserved, "", false, false},
+}
+
+func TestClassify(t *testing.T) {
+	for _, tt := range testCasesClassify {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		c := ipcalc.Classify(ips[0])
+		if c.Category != tt.expCategory {
+			t.Errorf("%q category got %q, want %q", tt.input, c.Category, tt.expCategory)
+		}
+		if c.Block != tt.expBlock {
+			t.Errorf("%q block got %q, want %q", tt.input, c.Block, tt.expBlock)
+		}
+		if c.Forwardable != tt.expFwd || c.GloballyReachable != tt.expGlobal {
+			t.Errorf("%q flags got %v/%v, want %v/%v",
+				tt.input, c.Forwardable, c.GloballyReachable, tt.expFwd, tt.expGlobal)
+		}
+	}
+}
+
+func TestClassString(t *testing.T) {
+	ip, _ := ipcalc.ParseIPv4Prefix("10.0.0.1/8")
+	exp := "private (Private-Use, 10.0.0.0/8, RFC1918), forwardable, not globally reachable"
+	if r := ipcalc.Classify(ip).String(); r != exp {
+		t.Errorf("got %q, want %q", r, exp)
+	}
+}
+
+var testCasesClassifyMulticast = []struct {
+	input      string
+	expGlobal  bool
+	expUnknown bool
+	expString  string
+}{
+	{"ff0e::1", true, false, "multicast (Multicast, ff00::/8, RFC4291), forwardable, globally reachable"},
+	{"ff02::1", false, false, "multicast (Multicast, ff00::/8, RFC4291), forwardable, not globally reachable"},
+	{"ff3e:30:2001:db8::/96", true, false, ""},
+	{"ff05::/16", false, false, ""},
+	// no single scope
+	{"ff00::/8", false, true, "multicast (Multicast, ff00::/8, RFC4291), forwardable"},
+	// IPv4 reachability depends on the group
+	{"224.0.0.5", false, true, "multicast (Multicast, 224.0.0.0/4, RFC5771), forwardable"},
+	{"233.252.0.1", false, true, ""},
+}
+
+func TestClassifyMulticastReachability(t *testing.T) {
+	for _, tt := range testCasesClassifyMulticast {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		c := ipcalc.Classify(ips[0])
+		if c.GloballyReachable != tt.expGlobal || c.ReachabilityUnknown != tt.expUnknown {
+			t.Errorf("%q reachable/unknown got %v/%v, want %v/%v",
+				tt.input, c.GloballyReachable, c.ReachabilityUnknown, tt.expGlobal, tt.expUnknown)
+		}
+		if tt.expString != "" && c.String() != tt.expString {
+			t.Errorf("%q got %q, want %q", tt.input, c.String(), tt.expString)
+		}
+	}
+}
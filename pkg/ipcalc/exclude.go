@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Exclude subtract excl prefixes from parent and return the minimal list
+// of prefixes covering what remains. Only ranges are compared, so it
+// never enumerate addresses. Parts of excl outside parent are ignored,
+// but every prefix must be of the parent family. Prefixes of other zone
+// than the parent one are ignored too (see Relation), results keep the
+// parent zone.
+func Exclude(parent IP, excl []IP) ([]IP, error) {
+	n := len(parent.Addr)
+	lo := addrToBig(parent.GetFirstAddr())
+	hi := addrToBig(parent.GetLastAddr())
+
+	spans := make([]span, 0, len(excl))
+	for _, ip := range excl {
+		if len(ip.Addr) != n {
+			return nil, fmt.Errorf("can't exclude %s from %s, different address family",
+				ip.GetAddrMask(), parent.GetAddrMask())
+		}
+		if ip.Zone != parent.Zone {
+			continue
+		}
+		s := ipSpan(ip)
+		if s.hi.Cmp(lo) < 0 || s.lo.Cmp(hi) > 0 {
+			continue
+		}
+		spans = append(spans, s)
+	}
+
+	// walk gaps between merged exclusions
+	r := []IP{}
+	cur := lo
+	for _, s := range mergeSpans(spans) {
+		if s.lo.Cmp(cur) > 0 {
+			r = append(r, rangeToPrefixes(cur, new(big.Int).Sub(s.lo, big.NewInt(1)), n)...)
+		}
+		cur = new(big.Int).Add(s.hi, big.NewInt(1))
+	}
+	if cur.Cmp(hi) <= 0 {
+		r = append(r, rangeToPrefixes(cur, hi, n)...)
+	}
+	for i := range r {
+		r[i].Zone = parent.Zone
+	}
+	return r, nil
+}
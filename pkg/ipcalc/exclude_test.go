@@ -0,0 +1,102 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"strings"
+	"testing"
+)
+
+var testCasesExclude = []struct {
+	parent string
+	excl   string
+	exp    string
+	expErr bool
+}{
+	{
+		"10.0.0.0/8", "10.1.2.0/24",
+		"10.0.0.0/16 10.1.0.0/23 10.1.3.0/24 10.1.4.0/22 10.1.8.0/21 10.1.16.0/20 10.1.32.0/19 10.1.64.0/18 " +
+			"10.1.128.0/17 10.2.0.0/15 10.4.0.0/14 10.8.0.0/13 10.16.0.0/12 10.32.0.0/11 10.64.0.0/10 10.128.0.0/9",
+		false,
+	},
+	{"192.168.0.0/24", "192.168.0.0/25", "192.168.0.128/25", false},
+	{"192.168.0.0/24", "192.168.0.0/26 192.168.0.192/26", "192.168.0.64/26 192.168.0.128/26", false},
+	{"192.168.0.0/24", "192.168.0.0/24", "", false},
+	{"192.168.0.0/24", "192.168.0.0/16", "", false},
+	{"192.168.0.0/24", "10.0.0.0/8", "192.168.0.0/24", false},
+	{"192.168.0.0/24", "", "192.168.0.0/24", false},
+	// overlapping and duplicate exclusions
+	{"192.168.0.0/24", "192.168.0.0/26 192.168.0.0/25 192.168.0.64/26", "192.168.0.128/25", false},
+	// exclusion partly outside the parent
+	{"192.168.1.0/24", "192.168.0.0-192.168.1.127", "192.168.1.128/25", false},
+	{"10.0.0.0/30", "10.0.0.1/32 10.0.0.2/32", "10.0.0.0/32 10.0.0.3/32", false},
+	{"0.0.0.0/0", "0.0.0.0/1", "128.0.0.0/1", false},
+	{"2001:db8::/32", "2001:db8::/33", "2001:db8:8000::/33", false},
+	{"::/0", "8000::/1", "::/1", false},
+	{"2001:db8::/126", "2001:db8::1/128", "2001:db8::/128 2001:db8::2/127", false},
+	// other zone is other link, zone of the parent is kept
+	{"fe80::%eth0/63", "fe80::%eth1/64", "fe80::%eth0/63", false},
+	{"fe80::%eth0/63", "fe80::/64", "fe80::%eth0/63", false},
+	{"fe80::%eth0/63", "fe80::%eth0/64", "fe80:0:0:1::%eth0/64", false},
+	{"fe80::/63", "fe80::%eth0/64 fe80:0:0:1::/64", "fe80::/64", false},
+
+	{"10.0.0.0/8", "2001:db8::/32", "", true},
+}
+
+func TestExclude(t *testing.T) {
+	for _, tt := range testCasesExclude {
+		parent, _, err := ipcalc.Parse(tt.parent, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Fatalf("%q unexpected error: %v", tt.parent, err)
+		}
+		var excl []ipcalc.IP
+		for _, s := range strings.Fields(tt.excl) {
+			r, _, err := ipcalc.Parse(s, ipcalc.ParseOptions{})
+			if err != nil {
+				t.Fatalf("%q unexpected error: %v", s, err)
+			}
+			excl = append(excl, r...)
+		}
+
+		ips, err := ipcalc.Exclude(parent[0], excl)
+		if (err != nil) != tt.expErr {
+			t.Errorf("%q - %q error got %v, want error %v", tt.parent, tt.excl, err, tt.expErr)
+			continue
+		}
+		var got []string
+		for _, ip := range ips {
+			got = append(got, ip.GetAddrMask())
+		}
+		if r := strings.Join(got, " "); r != tt.exp {
+			t.Errorf("%q - %q got %q, want %q", tt.parent, tt.excl, r, tt.exp)
+		}
+	}
+}
+
+func TestExcludeMany(t *testing.T) {
+	parent, _, _ := ipcalc.Parse("2001:db8::/32", ipcalc.ParseOptions{})
+	// every other /48 of the first 1000
+	var excl []ipcalc.IP
+	split, _ := ipcalc.Split(parent[0], 48, nil)
+	i := 0
+	for ip := range split {
+		if i == 1000 {
+			break
+		}
+		if i%2 == 0 {
+			excl = append(excl, ip)
+		}
+		i++
+	}
+
+	ips, err := ipcalc.Exclude(parent[0], excl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 500 /48 gaps between exclusions, then blocks up to the end of /32
+	if ips[0].GetAddrMask() != "2001:db8:1::/48" || ips[499].GetAddrMask() != "2001:db8:3e7::/48" {
+		t.Errorf("got %s ... %s", ips[0].GetAddrMask(), ips[499].GetAddrMask())
+	}
+	if last := ips[len(ips)-1].GetAddrMask(); last != "2001:db8:8000::/33" {
+		t.Errorf("last got %s, want 2001:db8:8000::/33", last)
+	}
+}
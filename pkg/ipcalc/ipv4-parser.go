@@ -6,6 +6,7 @@ package ipcalc
 
 import (
 	"fmt"
+	"math/bits"
 	"strconv"
 	"strings"
 )
@@ -17,14 +18,34 @@ import (
 //		mask []uint16
 //		pfx  uint8
 //	}
+//
+// The mask may be a prefix length (10.0.0.1/24), a dotted-decimal mask
+// (10.0.0.1/255.255.255.0 or 10.0.0.1 255.255.255.0) or a hex mask
+// (10.0.0.1/0xffffff00). Address without mask is a host route /32.
+// Address may be also a 32-bit integer (3232235777/24), hex
+// (0xC0A80101/24) or 32 character binary string.
 func ParseIPv4Prefix(s string) (IP, error) {
+	return ParseIPv4PrefixWith(s, ParseOptions{})
+}
+
+// ParseIPv4PrefixWith work as ParseIPv4Prefix, but the input is read
+// according to given options.
+func ParseIPv4PrefixWith(s string, opts ParseOptions) (IP, error) {
 	var out IP
 
-	// CIDR
+	// CIDR or "<addr> <mask>" as used in device configs
 	addr, pfxStr, ok := strings.Cut(s, "/")
 	if !ok {
-		p := fmt.Errorf("invalid addr, expected <ipv4>/mask, given: %s", s)
-		return out, p
+		f := strings.Fields(s)
+		switch len(f) {
+		case 1:
+			return parseBareIPv4(f[0], opts)
+		case 2:
+			addr, pfxStr = f[0], f[1]
+		default:
+			p := fmt.Errorf("invalid addr, expected <ipv4>/mask, given: %s", s)
+			return out, p
+		}
 	}
 
 	// Mask
@@ -33,7 +54,7 @@ func ParseIPv4Prefix(s string) (IP, error) {
 		return out, err
 	}
 
-	ip, err := parseOctets(addr)
+	ip, err := parseAddrIPv4(addr, opts)
 	if err != nil {
 		return out, err
 	}
@@ -44,48 +65,268 @@ func ParseIPv4Prefix(s string) (IP, error) {
 	return out, nil
 }
 
-// parseOctets convert number string to []uint16
-func parseOctets(addrStr string) ([]uint16, error) {
-	result := make([]uint16, 2)
+// parseBareIPv4 parse address given without mask. It is a host route,
+// or classful network when opts.Classful is set.
+func parseBareIPv4(addr string, opts ParseOptions) (IP, error) {
+	var out IP
+
+	ip, err := parseAddrIPv4(addr, opts)
+	if err != nil {
+		return out, err
+	}
+
+	pfx := uint8(32)
+	if opts.Classful {
+		if p, ok := classfulPrefix(ip); ok {
+			pfx = p
+		}
+	}
+
+	out.Addr = ip
+	out.Mask = prefixToMaskIPv4(pfx)
+	out.Pfx = pfx
+	return out, nil
+}
+
+// classfulPrefix return default prefix len of the address class.
+// Class D (multicast) and E (reserved) have no default mask.
+func classfulPrefix(ip []uint16) (uint8, bool) {
+	first := byte(ip[0] >> 8)
+	switch {
+	case first < 128: // A
+		return 8, true
+	case first < 192: // B
+		return 16, true
+	case first < 224: // C
+		return 24, true
+	default:
+		return 0, false
+	}
+}
+
+// parseAddrIPv4 parse address part with octet policy selected in opts.
+// Address may be also given as 32-bit integer (3232235777), hex
+// (0xC0A80101) or 32 character binary string.
+func parseAddrIPv4(addrStr string, opts ParseOptions) ([]uint16, error) {
+	if isBinaryIPv4(addrStr) {
+		return parseBinaryIPv4(addrStr)
+	}
+	if opts.Lenient {
+		return parseInetAton(addrStr)
+	}
+	if isIntAddr(addrStr) {
+		return parseIntIPv4(addrStr)
+	}
+	if !strings.Contains(addrStr, ".") {
+		return []uint16{}, fmt.Errorf("invalid addr, expected 4 octets or 32-bit integer: %s", addrStr)
+	}
+	return parseOctets(addrStr)
+}
+
+// isBinaryIPv4 check if s is 32 character binary string, or binary number
+// with 0b prefix.
+func isBinaryIPv4(s string) bool {
+	digits := s
+	if strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0B") {
+		digits = s[2:]
+	} else if len(s) != 32 {
+		return false
+	}
+	if digits == "" {
+		return false
+	}
+	return strings.Trim(digits, "01") == ""
+}
+
+// parseBinaryIPv4 convert binary string to []uint16.
+func parseBinaryIPv4(s string) ([]uint16, error) {
+	if strings.HasPrefix(s, "0b") || strings.HasPrefix(s, "0B") {
+		s = s[2:]
+	}
+	v, err := strconv.ParseUint(s, 2, 32)
+	if err != nil {
+		return []uint16{}, fmt.Errorf("invalid binary address: %s", s)
+	}
+	return u32ToAddr(uint32(v)), nil
+}
+
+// parseIntIPv4 convert 32-bit decimal or hex (0x prefix) integer string
+// to []uint16. Decimal value with leading zero is rejected as in octets.
+// Decimal value below 1.0.0.0 is rejected too, "10" is rather a short
+// form of 10.0.0.0 than address 0.0.0.10.
+func parseIntIPv4(s string) ([]uint16, error) {
+	var (
+		v   uint64
+		err error
+	)
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		v, err = strconv.ParseUint(s[2:], 16, 32)
+	case len(s) > 1 && s[0] == '0':
+		return []uint16{}, fmt.Errorf("invalid addr, leading zero in integer: %s", s)
+	default:
+		v, err = strconv.ParseUint(s, 10, 32)
+		if err == nil && v < 1<<24 {
+			return []uint16{}, fmt.Errorf("invalid addr, expected 4 octets, short form not allowed: %s", s)
+		}
+	}
+	if err != nil {
+		return []uint16{}, fmt.Errorf("invalid address, expected 32-bit integer: %s", s)
+	}
+	return u32ToAddr(uint32(v)), nil
+}
 
+// parseOctets convert x.x.x.x string to []uint16. This is the strict
+// policy, exactly four decimal octets without leading zeros are allowed.
+func parseOctets(addrStr string) ([]uint16, error) {
 	// check if string is correct ipv4 address
 	parts := strings.Split(addrStr, ".")
 	if len(parts) > 4 {
 		p := fmt.Errorf("invalid addr, to many octets: %s", addrStr)
-		return result, p
+		return []uint16{}, p
+	}
+	if len(parts) < 4 {
+		p := fmt.Errorf("invalid addr, expected 4 octets, short form not allowed: %s", addrStr)
+		return []uint16{}, p
 	}
 
-	var tmp uint8 = 0
+	var v uint32
 	// parse octets
-	for i, v := range parts {
-		o, err := strconv.ParseUint(v, 10, 8)
+	for _, p := range parts {
+		if len(p) > 1 && p[0] == '0' {
+			e := fmt.Errorf("invalid addr, leading zero in octet %q: %s", p, addrStr)
+			return []uint16{}, e
+		}
+		o, err := strconv.ParseUint(p, 10, 8)
 		if err != nil {
 			return []uint16{}, fmt.Errorf("invalid address: %s", addrStr)
 		}
+		v = v<<8 | uint32(o)
+	}
+
+	return u32ToAddr(v), nil
+}
 
-		if i%2 == 0 {
-			result[tmp] = 0x0 | (uint16(o) << 8)
+// parseInetAton convert address string to []uint16 with inet_aton(3)
+// rules. This is the lenient policy, allowed forms are:
+//
+//	a        32-bit value
+//	a.b      8-bit a, 24-bit b
+//	a.b.c    8-bit a and b, 16-bit c
+//	a.b.c.d  four 8-bit values
+//
+// Every part may be decimal, hex (0x prefix) or octal (0 prefix).
+func parseInetAton(addrStr string) ([]uint16, error) {
+	parts := strings.Split(addrStr, ".")
+	if len(parts) > 4 {
+		p := fmt.Errorf("invalid addr, to many octets: %s", addrStr)
+		return []uint16{}, p
+	}
+
+	var v uint32
+	for i, p := range parts {
+		n, err := parseInetAtonPart(p)
+		if err != nil {
+			return []uint16{}, fmt.Errorf("invalid address %s: %v", addrStr, err)
+		}
+
+		// last part fill all remaining bytes
+		width := 8
+		if i == len(parts)-1 {
+			width = 8 * (4 - i)
+		}
+		if width < 32 && n >= 1<<width {
+			e := fmt.Errorf("invalid address %s: part %q exceeds %d bits", addrStr, p, width)
+			return []uint16{}, e
+		}
+		if width == 32 {
+			v = uint32(n)
 		} else {
-			result[tmp] = result[tmp] | uint16(o)
-			tmp += 1
+			v = v<<width | uint32(n)
 		}
 	}
 
-	return result, nil
+	return u32ToAddr(v), nil
 }
 
-// parseMask valid if mask is corect and return []uint16 with mask and prefix
+// parseInetAtonPart parse single part of inet_aton address as C strtoul
+// with base 0 does.
+func parseInetAtonPart(p string) (uint64, error) {
+	var (
+		n   uint64
+		err error
+	)
+	switch {
+	case strings.HasPrefix(p, "0x") || strings.HasPrefix(p, "0X"):
+		n, err = strconv.ParseUint(p[2:], 16, 32)
+	case len(p) > 1 && p[0] == '0':
+		n, err = strconv.ParseUint(p[1:], 8, 32)
+	default:
+		n, err = strconv.ParseUint(p, 10, 32)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("wrong part %q", p)
+	}
+	return n, nil
+}
+
+// u32ToAddr split 32-bit IPv4 address to []uint16.
+func u32ToAddr(v uint32) []uint16 {
+	return []uint16{uint16(v >> 16), uint16(v)}
+}
+
+// parseMask valid if mask is corect and return []uint16 with mask and prefix.
+// Accepted forms are prefix length (24), dotted-decimal (255.255.255.0)
+// and hex (0xffffff00).
 func parseMask(m string) ([]uint16, uint8, error) {
-	mask := make([]uint16, 2)
+	switch {
+	case strings.HasPrefix(m, "0x") || strings.HasPrefix(m, "0X"):
+		v, err := strconv.ParseUint(m[2:], 16, 32)
+		if err != nil {
+			return make([]uint16, 2), 0, fmt.Errorf("invalid hex mask: %q", m)
+		}
+		return maskFromUint32(m, uint32(v))
+	case strings.Contains(m, "."):
+		if strings.Count(m, ".") != 3 {
+			return make([]uint16, 2), 0, fmt.Errorf("invalid mask: %q", m)
+		}
+		o, err := parseOctets(m)
+		if err != nil {
+			return make([]uint16, 2), 0, fmt.Errorf("invalid mask: %q", m)
+		}
+		return maskFromUint32(m, uint32(o[0])<<16|uint32(o[1]))
+	}
+
 	v, err := strconv.ParseUint(m, 10, 9)
 	if err != nil {
-		return mask, 0, fmt.Errorf("invalid prefix: %q", m)
+		return make([]uint16, 2), 0, fmt.Errorf("invalid prefix: %q", m)
 	}
 	if v > 32 {
-		return mask, 0, fmt.Errorf("invalid mask: %q", m)
+		return make([]uint16, 2), 0, fmt.Errorf("invalid mask: %q", m)
 	}
 
 	pfx := uint8(v)
+	return prefixToMaskIPv4(pfx), pfx, nil
+}
+
+// maskFromUint32 check that mask is contiguous and return it with prefix.
+// Bits in error are counted from 1 starting at the most significant bit.
+func maskFromUint32(m string, v uint32) ([]uint16, uint8, error) {
+	pfx := uint8(bits.LeadingZeros32(^v))
+	if rest := v << pfx; rest != 0 {
+		bad := int(pfx) + bits.LeadingZeros32(rest) + 1
+		p := fmt.Errorf(
+			"invalid mask %q, non-contiguous: bit %d set after host bits start at bit %d",
+			m, bad, pfx+1,
+		)
+		return make([]uint16, 2), 0, p
+	}
+	return prefixToMaskIPv4(pfx), pfx, nil
+}
+
+// prefixToMaskIPv4 change prefix len to IPv4 mask.
+func prefixToMaskIPv4(pfx uint8) []uint16 {
+	mask := make([]uint16, 2)
 	if pfx >= 16 {
 		mask[0] = 0xFFFF
 		mask[1] = 0xFFFF << (32 - pfx)
@@ -93,6 +334,14 @@ func parseMask(m string) ([]uint16, uint8, error) {
 		mask[0] = 0xFFFF << (16 - pfx)
 		mask[1] = 0x0000
 	}
+	return mask
+}
 
-	return mask, pfx, nil
+// IsIPv4Mask reports whether s is a valid dotted-decimal or hex netmask.
+func IsIPv4Mask(s string) bool {
+	if !strings.Contains(s, ".") && !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return false
+	}
+	_, _, err := parseMask(s)
+	return err == nil
 }
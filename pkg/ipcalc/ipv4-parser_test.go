@@ -2,6 +2,7 @@ package ipcalc_test
 
 import (
 	"goipcalc/pkg/ipcalc"
+	"strings"
 	"testing"
 )
 
@@ -37,10 +38,16 @@ var testCasesIPv4 = []struct {
 		uint8(0),
 	},
 
+	{
+		"192.168.0.1",
+		[]uint16{0xc0a8, 0x0001},
+		[]uint16{0xffff, 0xffff},
+		uint8(32),
+	},
+
 	// invalid
 	{"256.0.0.1/24", nil, nil, 0},    // wrong octet
 	{"192.168.0.1/33", nil, nil, 0},  // wrong mask
-	{"192.168.0.1", nil, nil, 0},     // no mask
 	{"192.192.168.0.1", nil, nil, 0}, // to long
 }
 
@@ -73,3 +80,244 @@ func TestParseIPv4Prefix(t *testing.T) {
 	}
 
 }
+
+var testCasesIPv4Mask = []struct {
+	input  string
+	expPfx uint8
+	ok     bool
+}{
+	{"10.0.0.1/255.255.255.0", 24, true},
+	{"10.0.0.1 255.255.255.0", 24, true},
+	{"10.0.0.1/0xffffff00", 24, true},
+	{"10.0.0.1/0XFFFE0000", 15, true},
+	{"10.0.0.1 0.0.0.0", 0, true},
+	{"10.0.0.1/255.255.255.255", 32, true},
+	{"10.0.0.1/255.0.255.0", 0, false},     // non-contiguous
+	{"10.0.0.1/0xff00ff00", 0, false},      // non-contiguous
+	{"10.0.0.1/255.255.0", 0, false},       // short mask
+	{"10.0.0.1/256.0.0.0", 0, false},       // wrong octet
+	{"10.0.0.1/0x1ffffffff", 0, false},     // to long
+	{"10.0.0.1 255.255.255.0 1", 0, false}, // to many fields
+}
+
+func TestParseIPv4PrefixMask(t *testing.T) {
+	for _, tt := range testCasesIPv4Mask {
+		ip, err := ipcalc.ParseIPv4Prefix(tt.input)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if ip.Pfx != tt.expPfx {
+			t.Errorf("%q prefix got %d, want %d", tt.input, ip.Pfx, tt.expPfx)
+		}
+		if !EqualU16(ip.Addr, []uint16{0x0a00, 0x0001}) {
+			t.Errorf("%q addr got %x", tt.input, ip.Addr)
+		}
+	}
+}
+
+func TestParseIPv4PrefixMaskBadBit(t *testing.T) {
+	_, err := ipcalc.ParseIPv4Prefix("10.0.0.1/255.0.255.0")
+	if err == nil || !strings.Contains(err.Error(), "bit 17") {
+		t.Errorf("expected error naming bit 17, got %v", err)
+	}
+}
+
+var testCasesIPv4Classful = []struct {
+	input  string
+	expPfx uint8
+}{
+	{"10.1.2.3", 8},
+	{"127.0.0.1", 8},
+	{"172.16.5.4", 16},
+	{"191.255.0.1", 16},
+	{"192.168.1.1", 24},
+	{"223.0.0.1", 24},
+	{"224.0.0.1", 32}, // class D has no default mask
+	{"240.0.0.1", 32}, // class E has no default mask
+	{"10.1.2.3/24", 24},
+}
+
+func TestParseIPv4PrefixClassful(t *testing.T) {
+	opts := ipcalc.ParseOptions{Classful: true}
+	for _, tt := range testCasesIPv4Classful {
+		ip, err := ipcalc.ParseIPv4PrefixWith(tt.input, opts)
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if ip.Pfx != tt.expPfx {
+			t.Errorf("%q prefix got %d, want %d", tt.input, ip.Pfx, tt.expPfx)
+		}
+	}
+}
+
+var testCasesIPv4Octets = []struct {
+	input      string
+	expStrict  []uint16 // nil when strict policy must fail
+	expLenient []uint16 // nil when lenient policy must fail
+}{
+	// four decimal octets
+	{"10.0.0.1", []uint16{0x0a00, 0x0001}, []uint16{0x0a00, 0x0001}},
+	{"0.0.0.0", []uint16{0x0000, 0x0000}, []uint16{0x0000, 0x0000}},
+	{"255.255.255.255", []uint16{0xffff, 0xffff}, []uint16{0xffff, 0xffff}},
+	{"192.168.100.200", []uint16{0xc0a8, 0x64c8}, []uint16{0xc0a8, 0x64c8}},
+
+	// short forms
+	{"167772161", []uint16{0x0a00, 0x0001}, []uint16{0x0a00, 0x0001}},
+	{"0x0a000001", []uint16{0x0a00, 0x0001}, []uint16{0x0a00, 0x0001}},
+	{"01200000001", nil, []uint16{0x0a00, 0x0001}},
+	{"4294967295", []uint16{0xffff, 0xffff}, []uint16{0xffff, 0xffff}},
+	{"4294967296", nil, nil}, // a above 32 bits
+	{"0", nil, []uint16{0x0000, 0x0000}},
+	{"10", nil, []uint16{0x0000, 0x000a}}, // strict rejects short form, lenient reads 0.0.0.10
+	{"16777215", nil, []uint16{0x00ff, 0xffff}},
+	{"16777216", []uint16{0x0100, 0x0000}, []uint16{0x0100, 0x0000}},
+	{"0xa", []uint16{0x0000, 0x000a}, []uint16{0x0000, 0x000a}}, // hex is explicit
+	{"0x", nil, nil},
+	{"bogus", nil, nil},
+	{"10.1", nil, []uint16{0x0a00, 0x0001}},
+	{"10.1.2", nil, []uint16{0x0a01, 0x0002}},
+	{"127.1", nil, []uint16{0x7f00, 0x0001}},
+	{"10.65535", nil, []uint16{0x0a00, 0xffff}},
+	{"10.16777215", nil, []uint16{0x0aff, 0xffff}},
+	{"10.16777216", nil, nil}, // b above 24 bits
+	{"10.1.65535", nil, []uint16{0x0a01, 0xffff}},
+	{"10.1.65536", nil, nil}, // c above 16 bits
+	{"256.1", nil, nil},      // a above 8 bits
+
+	// leading zeros and octal
+	{"010.0.0.1", nil, []uint16{0x0800, 0x0001}},
+	{"10.0.0.010", nil, []uint16{0x0a00, 0x0008}},
+	{"10.00.0.1", nil, []uint16{0x0a00, 0x0001}},
+	{"0377.0377.0377.0377", nil, []uint16{0xffff, 0xffff}},
+	{"0400.0.0.1", nil, nil}, // octal above 255
+	{"08.0.0.1", nil, nil},   // wrong octal digit
+	{"09.0.0.1", nil, nil},   // wrong octal digit
+
+	// hex
+	{"0x0a.0.0.1", nil, []uint16{0x0a00, 0x0001}},
+	{"0X0A.0x0.0x0.0x1", nil, []uint16{0x0a00, 0x0001}},
+	{"0xff.0xff.0xff.0xff", nil, []uint16{0xffff, 0xffff}},
+	{"0x100.0.0.1", nil, nil}, // hex above 255
+	{"0x.0.0.1", nil, nil},    // empty hex
+	{"0xg.0.0.1", nil, nil},   // wrong hex digit
+
+	// binary
+	{"11000000101010000000000100000001", []uint16{0xc0a8, 0x0101}, []uint16{0xc0a8, 0x0101}},
+	{"0b11000000101010000000000100000001", []uint16{0xc0a8, 0x0101}, []uint16{0xc0a8, 0x0101}},
+	{"0b1010", []uint16{0x0000, 0x000a}, []uint16{0x0000, 0x000a}},
+	{"0b", nil, nil},
+	{"0b110000001010100000000001000000011", nil, nil}, // 33 bits
+
+	// invalid for both
+	{"256.0.0.1", nil, nil},
+	{"1.2.3.4.5", nil, nil},
+	{"1..2.3", nil, nil},
+	{"1.2.3.", nil, nil},
+	{".1.2.3", nil, nil},
+	{"", nil, nil},
+	{"a.b.c.d", nil, nil},
+	{"-1.0.0.0", nil, nil},
+	{"+1.0.0.0", nil, nil},
+	{" 1.0.0.0", nil, nil},
+}
+
+func TestParseIPv4Octets(t *testing.T) {
+	policies := []struct {
+		name string
+		opts ipcalc.ParseOptions
+	}{
+		{"strict", ipcalc.ParseOptions{}},
+		{"lenient", ipcalc.ParseOptions{Lenient: true}},
+	}
+
+	for _, tt := range testCasesIPv4Octets {
+		for _, p := range policies {
+			exp := tt.expStrict
+			if p.opts.Lenient {
+				exp = tt.expLenient
+			}
+
+			ip, err := ipcalc.ParseIPv4PrefixWith(tt.input+"/32", p.opts)
+			if exp == nil {
+				if err == nil {
+					t.Errorf("%s %q expected error, got %x", p.name, tt.input, ip.Addr)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s %q unexpected error: %v", p.name, tt.input, err)
+				continue
+			}
+			if !EqualU16(ip.Addr, exp) {
+				t.Errorf("%s %q addr got %x, want %x", p.name, tt.input, ip.Addr, exp)
+			}
+		}
+	}
+}
+
+func TestParseIPv4OctetsStrictErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		expErr string
+	}{
+		{"10.1/8", "short form"},
+		{"10/8", "short form"},
+		{"010.0.0.1/8", "leading zero"},
+	}
+	for _, tt := range tests {
+		_, err := ipcalc.ParseIPv4Prefix(tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.expErr) {
+			t.Errorf("%q expected error with %q, got %v", tt.input, tt.expErr, err)
+		}
+	}
+}
+
+var testCasesIPv4Int = []struct {
+	input   string
+	expAddr []uint16
+	expPfx  uint8
+}{
+	{"3232235777/24", []uint16{0xc0a8, 0x0101}, 24},
+	{"0xC0A80101/24", []uint16{0xc0a8, 0x0101}, 24},
+	{"0xc0a80101", []uint16{0xc0a8, 0x0101}, 32},
+	{"11000000101010000000000100000001/16", []uint16{0xc0a8, 0x0101}, 16},
+	{"0x0/0", []uint16{0x0000, 0x0000}, 0},
+
+	// invalid
+	{"0/0", nil, 0},            // short form
+	{"10/8", nil, 0},           // short form, not 0.0.0.10
+	{"4294967296/24", nil, 0},  // above 32 bits
+	{"0x1C0A80101/24", nil, 0}, // above 32 bits
+	{"03232235777/24", nil, 0}, // leading zero
+	{"0xg/24", nil, 0},         // wrong hex
+}
+
+func TestParseIPv4PrefixInt(t *testing.T) {
+	for _, tt := range testCasesIPv4Int {
+		ip, err := ipcalc.ParseIPv4Prefix(tt.input)
+		if tt.expAddr == nil {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if !EqualU16(ip.Addr, tt.expAddr) {
+			t.Errorf("%q addr got %x, want %x", tt.input, ip.Addr, tt.expAddr)
+		}
+		if ip.Pfx != tt.expPfx {
+			t.Errorf("%q prefix got %d, want %d", tt.input, ip.Pfx, tt.expPfx)
+		}
+	}
+}
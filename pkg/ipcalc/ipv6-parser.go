@@ -6,6 +6,7 @@ package ipcalc
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"unicode"
@@ -18,22 +19,41 @@ import (
 //		mask []uint16
 //		pfx  uint8
 //	}
+//
+// Address without prefix length is a host route /128. Address may carry
+// a zone (fe80::1%eth0/64), it is kept in IP.Zone. The last 32 bits
+// may be written as dotted IPv4 (::ffff:192.0.2.1, 64:ff9b::198.51.100.7).
+// Whole address may be also given as 128-bit decimal or hex (0x prefix)
+// integer.
 func ParseIPv6Prefix(s string) (IP, error) {
 	var out IP
 
 	// CIDR
 	addr, pfxStr, ok := strings.Cut(s, "/")
 	if !ok {
-		p := fmt.Errorf("invalid addr, expected <ipv6>/<mask>, given: %s", s)
-		return out, p
+		pfxStr = "128"
 	}
-	// check if ipv6:ipv4 address
-	if strings.Contains(addr, ".") {
-		return out, fmt.Errorf("invalid addr, not allowe ipv4 format: %s", s)
+	// zone, e.g. fe80::1%eth0 or fe80::1%2
+	addr, zone, ok := strings.Cut(addr, "%")
+	if ok && !validZone(zone) {
+		return out, fmt.Errorf("invalid addr, wrong zone %q: %s", zone, s)
 	}
-	// check if zone in address
-	if strings.Contains(addr, "%") {
-		return out, fmt.Errorf("invalid addr, not allowed zones: %s", s)
+	// 128-bit integer, decimal or hex
+	if !strings.Contains(addr, ":") {
+		tmpAddr, err := parseIntIPv6(addr)
+		if err != nil {
+			return out, err
+		}
+		addr = Format{Expanded: true}.Addr(tmpAddr)
+	}
+	// ipv6:ipv4 address, change dotted tail to last two hextets
+	if strings.Contains(addr, ".") {
+		out.Dotted = true
+		a, err := replaceIPv4Tail(addr)
+		if err != nil {
+			return out, err
+		}
+		addr = a
 	}
 	// parse prefix
 	pfxU, err := strconv.ParseUint(pfxStr, 10, 8)
@@ -108,9 +128,49 @@ func ParseIPv6Prefix(s string) (IP, error) {
 	out.Addr = tmpAddr
 	out.Mask = parseMaskHextet(pfx)
 	out.Pfx = pfx
+	out.Zone = zone
 	return out, nil
 }
 
+// validZone reports whether zone can be IPv6 zone, interface name or
+// index. Address separators are not allowed, so zone can't swallow the
+// rest of a range (fe80::1%eth0-fe80::2).
+func validZone(zone string) bool {
+	return zone != "" && !strings.ContainsAny(zone, "%: \t")
+}
+
+// parseIntIPv6 convert 128-bit decimal or hex (0x prefix) integer string
+// to []uint16.
+func parseIntIPv6(s string) ([]uint16, error) {
+	var (
+		v  *big.Int
+		ok bool
+	)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, ok = new(big.Int).SetString(s[2:], 16)
+	} else {
+		v, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok || v.Sign() < 0 || v.BitLen() > 128 {
+		return nil, fmt.Errorf("invalid address, expected 128-bit integer: %s", s)
+	}
+	return bigToAddr(v, 8), nil
+}
+
+// replaceIPv4Tail change embedded IPv4 tail (::ffff:192.0.2.1) to two
+// hextets (::ffff:c000:201), so address can be parsed as pure IPv6.
+func replaceIPv4Tail(addr string) (string, error) {
+	idx := strings.LastIndex(addr, ":")
+	if idx == -1 {
+		return "", fmt.Errorf("invalid addr, ipv4 tail without ipv6 part: %s", addr)
+	}
+	v4, err := parseOctets(addr[idx+1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid addr, wrong ipv4 tail: %v", err)
+	}
+	return fmt.Sprintf("%s:%x:%x", addr[:idx], v4[0], v4[1]), nil
+}
+
 // parseHextet function convert string hex value to uint16
 func parseHextet(p string) (uint16, error) {
 	if len(p) == 0 || len(p) > 4 {
@@ -36,13 +36,58 @@ var testCasesIPv6 = []struct {
 		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0, 0, 0, 0},
 		uint8(64),
 	},
+	{
+		"2001:db8::1",
+		[]uint16{0x2001, 0x0db8, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0001},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff},
+		uint8(128),
+	},
+	{
+		"2001::1234:192.168.11.24/64",
+		[]uint16{0x2001, 0, 0, 0, 0, 0x1234, 0xc0a8, 0x0b18},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0, 0, 0, 0},
+		uint8(64),
+	},
+	{
+		"::ffff:192.0.2.1/128",
+		[]uint16{0, 0, 0, 0, 0, 0xffff, 0xc000, 0x0201},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff},
+		uint8(128),
+	},
+	{
+		"64:ff9b::198.51.100.7/96",
+		[]uint16{0x0064, 0xff9b, 0, 0, 0, 0, 0xc633, 0x6407},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0, 0},
+		uint8(96),
+	},
+	{
+		"2001:db8::10.0.0.1/120",
+		[]uint16{0x2001, 0x0db8, 0, 0, 0, 0, 0x0a00, 0x0001},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xff00},
+		uint8(120),
+	},
+	{
+		"1:2:3:4:5:6:1.2.3.4/64",
+		[]uint16{1, 2, 3, 4, 5, 6, 0x0102, 0x0304},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0, 0, 0, 0},
+		uint8(64),
+	},
+	{
+		"::0.0.0.1/128",
+		[]uint16{0, 0, 0, 0, 0, 0, 0, 1},
+		[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff},
+		uint8(128),
+	},
 
 	// invalid
-	{"2001:db8::1/129", nil, nil, 0},             // wrong mask
-	{"2001:db8::1", nil, nil, 0},                 // no mask
-	{"2001:::1/64", nil, nil, 0},                 // triple :
-	{"2001::1234::1/64", nil, nil, 0},            // double ::
-	{"2001::1234:192.168.11.24/64", nil, nil, 0}, // no supported
+	{"2001:db8::1/129", nil, nil, 0},          // wrong mask
+	{"2001:::1/64", nil, nil, 0},              // triple :
+	{"2001::1234::1/64", nil, nil, 0},         // double ::
+	{"1:2:3:4:5:6:7:1.2.3.4/64", nil, nil, 0}, // to many hextets
+	{"::1.2.3/96", nil, nil, 0},               // short ipv4 tail
+	{"::256.1.1.1/96", nil, nil, 0},           // wrong ipv4 tail
+	{"::1.2.3.4:5/96", nil, nil, 0},           // ipv4 not at the end
+	{"1.2.3.4/32", nil, nil, 0},               // pure ipv4
 }
 
 func TestParseIPv6Prefix(t *testing.T) {
@@ -74,3 +119,85 @@ func TestParseIPv6Prefix(t *testing.T) {
 	}
 
 }
+
+var testCasesIPv6Zone = []struct {
+	input       string
+	expZone     string
+	expAddrMask string
+	expNetwork  string
+	ok          bool
+}{
+	{"fe80::1%eth0/64", "eth0", "fe80::1%eth0/64", "fe80::", true},
+	{"fe80::1%2/64", "2", "fe80::1%2/64", "fe80::", true},
+	{"fe80::1%eth0", "eth0", "fe80::1%eth0/128", "fe80::1", true},
+	{"2001:db8::1/64", "", "2001:db8::1/64", "2001:db8::", true},
+	{"fe80::1%/64", "", "", "", false},          // empty zone
+	{"fe80::1%a%b/64", "", "", "", false},       // double zone
+	{"fe80::1%eth 0/64", "", "", "", false},     // space in zone
+	{"fe80::1%eth0-fe80::2", "", "", "", false}, // address in zone
+	{"fe80::zz%eth0/64", "", "", "", false},     // wrong address
+}
+
+func TestParseIPv6PrefixZone(t *testing.T) {
+	for _, tt := range testCasesIPv6Zone {
+		ip, err := ipcalc.ParseIPv6Prefix(tt.input)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if ip.Zone != tt.expZone {
+			t.Errorf("%q zone got %q, want %q", tt.input, ip.Zone, tt.expZone)
+		}
+		if r := ip.GetAddrMask(); r != tt.expAddrMask {
+			t.Errorf("%q addr mask got %q, want %q", tt.input, r, tt.expAddrMask)
+		}
+		if r := ipcalc.NiceAddr(ip.GetFirstAddr()); r != tt.expNetwork {
+			t.Errorf("%q network got %q, want %q", tt.input, r, tt.expNetwork)
+		}
+	}
+}
+
+var testCasesIPv6Int = []struct {
+	input   string
+	expAddr []uint16
+	expPfx  uint8
+}{
+	{"42540766411282592856903984951653826561/64", []uint16{0x2001, 0x0db8, 0, 0, 0, 0, 0, 1}, 64},
+	{"0x20010db8000000000000000000000001/64", []uint16{0x2001, 0x0db8, 0, 0, 0, 0, 0, 1}, 64},
+	{"1/128", []uint16{0, 0, 0, 0, 0, 0, 0, 1}, 128},
+	{"0xffffffffffffffffffffffffffffffff", []uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff}, 128},
+
+	// invalid
+	{"340282366920938463463374607431768211456/64", nil, 0}, // above 128 bits
+	{"0x1ffffffffffffffffffffffffffffffff", nil, 0},        // above 128 bits
+	{"-1/64", nil, 0},   // negative
+	{"12ab/64", nil, 0}, // hex without 0x
+}
+
+func TestParseIPv6PrefixInt(t *testing.T) {
+	for _, tt := range testCasesIPv6Int {
+		ip, err := ipcalc.ParseIPv6Prefix(tt.input)
+		if tt.expAddr == nil {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if !EqualU16(ip.Addr, tt.expAddr) {
+			t.Errorf("%q addr got %x, want %x", tt.input, ip.Addr, tt.expAddr)
+		}
+		if ip.Pfx != tt.expPfx {
+			t.Errorf("%q prefix got %d, want %d", tt.input, ip.Pfx, tt.expPfx)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Multicast describe decoded IPv6 multicast address (ff00::/8).
+type Multicast struct {
+	// Flags from the 4 bits after ff: R embedded RP (RFC 3956), P prefix
+	// based (RFC 3306), T transient (not well-known) address.
+	R, P, T bool
+	// Scope of the address, 1 interface-local ... e global.
+	Scope uint8
+	// GroupID is 112 bits group ID, or 32 bits for prefix based address.
+	GroupID *big.Int
+	// Prefix is unicast prefix embedded in RFC 3306 address, nil when
+	// not present.
+	Prefix *IP
+	// RP is rendezvous point embedded in RFC 3956 address, nil when
+	// not present.
+	RP []uint16
+	// Errors list RFC 3306/3956 violations, e.g. "P=1 without T=1".
+	// Prefix and RP are not decoded from invalid prefix len.
+	Errors []string
+}
+
+// multicastScopes name scope values (RFC 7346).
+var multicastScopes = map[uint8]string{
+	0x0: "reserved",
+	0x1: "interface-local",
+	0x2: "link-local",
+	0x3: "realm-local",
+	0x4: "admin-local",
+	0x5: "site-local",
+	0x8: "organization-local",
+	0xe: "global",
+	0xf: "reserved",
+}
+
+// ScopeName return name of the multicast scope, e.g. link-local.
+func (m Multicast) ScopeName() string {
+	if n, ok := multicastScopes[m.Scope]; ok {
+		return n
+	}
+	return "unassigned"
+}
+
+// FlagsString return flags in "R=0 P=1 T=1" form.
+func (m Multicast) FlagsString() string {
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	r := fmt.Sprintf("R=%d P=%d T=%d", b(m.R), b(m.P), b(m.T))
+
+	var notes []string
+	if m.R {
+		notes = append(notes, "embedded RP")
+	}
+	if m.P {
+		notes = append(notes, "prefix based")
+	}
+	if m.T {
+		notes = append(notes, "transient")
+	} else {
+		notes = append(notes, "well-known")
+	}
+	return r + " (" + strings.Join(notes, ", ") + ")"
+}
+
+// DecodeMulticast return DecodeMulticast(ip), so types embedding IP
+// (like Allocation) can be decoded too.
+func (ip IP) DecodeMulticast() (Multicast, bool) {
+	return DecodeMulticast(ip)
+}
+
+// DecodeMulticast decode IPv6 multicast address. The second value is
+// false when ip is not IPv6 multicast. Malformed address is still
+// decoded, violations are listed in Multicast.Errors.
+//
+// Prefix based address (RFC 3306) is ff3s:00LL:PPPP:PPPP:PPPP:PPPP:GGGG:GGGG
+// where LL is prefix len and P the prefix. Embedded RP address (RFC 3956)
+// is ff7s:0RLL:... where R is RP interface ID added to the prefix.
+func DecodeMulticast(ip IP) (Multicast, bool) {
+	var m Multicast
+	a := ip.Addr
+	if len(a) != 8 || a[0]>>8 != 0xff {
+		return m, false
+	}
+
+	flags := (a[0] >> 4) & 0xf
+	m.R = flags&0x4 != 0
+	m.P = flags&0x2 != 0
+	m.T = flags&0x1 != 0
+	m.Scope = uint8(a[0] & 0xf)
+	// RFC 3956 section 3, RFC 3306 section 4
+	if m.R && !m.P {
+		m.Errors = append(m.Errors, "R=1 without P=1")
+	}
+	if m.P && !m.T {
+		m.Errors = append(m.Errors, "P=1 without T=1")
+	}
+
+	if !m.P {
+		m.GroupID = addrToBig(a[1:])
+		return m, true
+	}
+
+	// RFC 3306 / RFC 3956 layout
+	m.GroupID = addrToBig(a[6:])
+	plen := uint8(a[1] & 0xff)
+	if plen > 64 {
+		m.Errors = append(m.Errors, fmt.Sprintf("embedded prefix len %d above 64", plen))
+		return m, true
+	}
+	if plen == 0 {
+		// source-specific multicast ff3x::/96, no prefix
+		return m, true
+	}
+
+	prefix := newIP([]uint16{a[2], a[3], a[4], a[5], 0, 0, 0, 0}, plen)
+	prefix.Addr = prefix.GetFirstAddr()
+	m.Prefix = &prefix
+
+	if m.R {
+		riid := (a[1] >> 8) & 0xf
+		m.RP = append([]uint16(nil), prefix.Addr...)
+		m.RP[7] = riid
+	}
+	return m, true
+}
+
+// MulticastMAC return Ethernet MAC address of the multicast group:
+// 01:00:5e and low 23 bits of the address for IPv4 (RFC 1112), 33:33 and
+// low 32 bits for IPv6 (RFC 2464). The second value is false when ip is
+// not multicast.
+func MulticastMAC(ip IP) (string, bool) {
+	a := ip.Addr
+	switch {
+	case len(a) == 2 && a[0]>>12 == 0xe:
+		return fmt.Sprintf("01:00:5e:%02x:%02x:%02x",
+			a[0]&0x7f, a[1]>>8, a[1]&0xff), true
+	case len(a) == 8 && a[0]>>8 == 0xff:
+		return fmt.Sprintf("33:33:%02x:%02x:%02x:%02x",
+			a[6]>>8, a[6]&0xff, a[7]>>8, a[7]&0xff), true
+	}
+	return "", false
+}
+
+// MACOverlap return other 31 IPv4 multicast groups mapped to the same
+// MAC address as ip. Only 23 of 28 group bits go to the MAC, so 32
+// groups share one. Return nil when ip is not IPv4 multicast.
+func MACOverlap(ip IP) [][]uint16 {
+	a := ip.Addr
+	if len(a) != 2 || a[0]>>12 != 0xe {
+		return nil
+	}
+
+	var result [][]uint16
+	for i := range uint16(32) {
+		// 5 lost bits are the low nibble of the first octet and the
+		// high bit of the second one.
+		hi := 0xe000 | (i>>1)<<8 | (i&1)<<7 | a[0]&0x7f
+		if hi == a[0] {
+			continue
+		}
+		result = append(result, []uint16{hi, a[1]})
+	}
+	return result
+}
+
+// rows return detail rows of the decoded multicast address.
+func (m Multicast) rows(f Format) [][2]string {
+	result := [][2]string{
+		{"Multicast flags", m.FlagsString()},
+		{"Multicast scope", fmt.Sprintf("%x (%s)", m.Scope, m.ScopeName())},
+		{"Group ID", fmt.Sprintf("0x%x", m.GroupID)},
+	}
+	if m.Prefix != nil {
+		result = append(result, [2]string{"Embedded prefix", f.AddrMask(*m.Prefix)})
+	}
+	if m.RP != nil {
+		result = append(result, [2]string{"RP address", f.Addr(m.RP)})
+	}
+	for _, e := range m.Errors {
+		result = append(result, [2]string{"Multicast error", e})
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"slices"
+	"testing"
+)
+
+var testCasesMulticast = []struct {
+	input     string
+	expFlags  string
+	expScope  string
+	expGroup  string
+	expPrefix string
+	expRP     string
+	ok        bool
+}{
+	{"ff02::1", "R=0 P=0 T=0 (well-known)", "link-local", "1", "", "", true},
+	{"ff05::1:3", "R=0 P=0 T=0 (well-known)", "site-local", "65539", "", "", true},
+	{"ff1e::1234", "R=0 P=0 T=1 (transient)", "global", "4660", "", "", true},
+	{"ff01::2", "R=0 P=0 T=0 (well-known)", "interface-local", "2", "", "", true},
+	{"ff06::1", "R=0 P=0 T=0 (well-known)", "unassigned", "1", "", "", true},
+	// RFC 3306 unicast-prefix-based
+	{"ff3e:30:2001:db8::1234", "R=0 P=1 T=1 (prefix based, transient)", "global", "4660", "2001:db8::/48", "", true},
+	{"ff3e:20:2001:db8::8000:1", "R=0 P=1 T=1 (prefix based, transient)", "global", "2147483649", "2001:db8::/32", "", true},
+	// source-specific multicast
+	{"ff3e::8000:1", "R=0 P=1 T=1 (prefix based, transient)", "global", "2147483649", "", "", true},
+	// RFC 3956 embedded RP
+	{"ff7e:140:2001:db8:be00::1", "R=1 P=1 T=1 (embedded RP, prefix based, transient)", "global", "1", "2001:db8:be00::/64", "2001:db8:be00::1", true},
+	{"ff75:520:2001:db8::5", "R=1 P=1 T=1 (embedded RP, prefix based, transient)", "site-local", "5", "2001:db8::/32", "2001:db8::5", true},
+
+	// malformed, decoded without the prefix
+	{"ff3e:41:2001:db8::1", "R=0 P=1 T=1 (prefix based, transient)", "global", "1", "", "", true},
+
+	// invalid
+	{"fe80::1", "", "", "", "", "", false},   // not multicast
+	{"224.0.0.1", "", "", "", "", "", false}, // IPv4
+}
+
+func TestDecodeMulticast(t *testing.T) {
+	for _, tt := range testCasesMulticast {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		m, ok := ipcalc.DecodeMulticast(ips[0])
+		if ok != tt.ok {
+			t.Errorf("%q ok got %v, want %v", tt.input, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if r := m.FlagsString(); r != tt.expFlags {
+			t.Errorf("%q flags got %q, want %q", tt.input, r, tt.expFlags)
+		}
+		if r := m.ScopeName(); r != tt.expScope {
+			t.Errorf("%q scope got %q, want %q", tt.input, r, tt.expScope)
+		}
+		if r := m.GroupID.String(); r != tt.expGroup {
+			t.Errorf("%q group got %q, want %q", tt.input, r, tt.expGroup)
+		}
+
+		var prefix, rp string
+		if m.Prefix != nil {
+			prefix = m.Prefix.GetAddrMask()
+		}
+		if m.RP != nil {
+			rp = ipcalc.NiceAddr(m.RP)
+		}
+		if prefix != tt.expPrefix {
+			t.Errorf("%q prefix got %q, want %q", tt.input, prefix, tt.expPrefix)
+		}
+		if rp != tt.expRP {
+			t.Errorf("%q RP got %q, want %q", tt.input, rp, tt.expRP)
+		}
+	}
+}
+
+var testCasesMulticastMAC = []struct {
+	input      string
+	expMAC     string
+	expOverlap []string // first and last of the overlapping groups
+	ok         bool
+}{
+	{"224.0.0.1", "01:00:5e:00:00:01", []string{"224.128.0.1", "239.128.0.1"}, true},
+	{"239.255.255.250", "01:00:5e:7f:ff:fa", []string{"224.127.255.250", "239.127.255.250"}, true},
+	{"232.129.2.3/32", "01:00:5e:01:02:03", []string{"224.1.2.3", "239.129.2.3"}, true},
+	{"ff02::1", "33:33:00:00:00:01", nil, true},
+	{"ff02::1:ff00:1234", "33:33:ff:00:12:34", nil, true},
+	{"ff05::1:3", "33:33:00:01:00:03", nil, true},
+
+	{"192.0.2.1", "", nil, false},
+	{"240.0.0.1", "", nil, false},
+	{"2001:db8::1", "", nil, false},
+}
+
+func TestMulticastMAC(t *testing.T) {
+	for _, tt := range testCasesMulticastMAC {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		mac, ok := ipcalc.MulticastMAC(ips[0])
+		if ok != tt.ok || mac != tt.expMAC {
+			t.Errorf("%q got %q %v, want %q %v", tt.input, mac, ok, tt.expMAC, tt.ok)
+		}
+
+		overlap := ipcalc.MACOverlap(ips[0])
+		if tt.expOverlap == nil {
+			if overlap != nil {
+				t.Errorf("%q overlap got %d groups, want none", tt.input, len(overlap))
+			}
+			continue
+		}
+		if len(overlap) != 31 {
+			t.Errorf("%q overlap got %d groups, want 31", tt.input, len(overlap))
+			continue
+		}
+		first, last := ipcalc.NiceAddr(overlap[0]), ipcalc.NiceAddr(overlap[30])
+		if first != tt.expOverlap[0] || last != tt.expOverlap[1] {
+			t.Errorf("%q overlap got %s..%s, want %s..%s",
+				tt.input, first, last, tt.expOverlap[0], tt.expOverlap[1])
+		}
+		for _, o := range overlap {
+			m, _ := ipcalc.MulticastMAC(ipcalc.IP{Addr: o})
+			if m != mac {
+				t.Errorf("%q overlap %s maps to %q, want %q",
+					tt.input, ipcalc.NiceAddr(o), m, mac)
+			}
+		}
+	}
+}
+
+var testCasesMulticastErrors = []struct {
+	input   string
+	expErrs []string
+}{
+	{"ff3e:30:2001:db8::1234", nil},
+	{"ff7e:140:2001:db8:be00::1", nil},
+	{"ff3e:41:2001:db8::1", []string{"embedded prefix len 65 above 64"}},
+	// RP needs prefix based address
+	{"ff5e::1", []string{"R=1 without P=1"}},
+	// prefix based address must be transient
+	{"ff2e:30:2001:db8::1", []string{"P=1 without T=1"}},
+	{"ff4e::1", []string{"R=1 without P=1"}},
+	{"ff6e:140:2001:db8:be00::1", []string{"P=1 without T=1"}},
+}
+
+func TestDecodeMulticastErrors(t *testing.T) {
+	for _, tt := range testCasesMulticastErrors {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		m, ok := ipcalc.DecodeMulticast(ips[0])
+		if !ok {
+			t.Errorf("%q not decoded", tt.input)
+			continue
+		}
+		if !slices.Equal(m.Errors, tt.expErrs) {
+			t.Errorf("%q errors got %q, want %q", tt.input, m.Errors, tt.expErrs)
+		}
+	}
+}
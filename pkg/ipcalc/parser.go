@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Normalization is a set of changes Parse made to the input before the
+// address was parsed.
+type Normalization uint8
+
+const (
+	// NormScheme mean URL scheme, user info and path were removed
+	// (https://[2001:db8::1]/index.html).
+	NormScheme Normalization = 1 << iota
+	// NormBrackets mean brackets around IPv6 address were removed
+	// ([2001:db8::1]).
+	NormBrackets
+	// NormPort mean port number was removed (192.0.2.1:8080).
+	NormPort
+	// NormReverse mean reverse DNS name was converted to the prefix
+	// (168.192.in-addr.arpa).
+	NormReverse
+)
+
+// String return comma separated list of applied normalizations.
+func (n Normalization) String() string {
+	var r []string
+	if n&NormScheme != 0 {
+		r = append(r, "stripped URL scheme")
+	}
+	if n&NormBrackets != 0 {
+		r = append(r, "stripped brackets")
+	}
+	if n&NormPort != 0 {
+		r = append(r, "stripped port")
+	}
+	if n&NormReverse != 0 {
+		r = append(r, "converted reverse DNS name")
+	}
+	return strings.Join(r, ", ")
+}
+
+// Parse detect address family of s and parse it to list of IP. Beside
+// forms accepted by ParseIPv4PrefixWith, ParseIPv6Prefix and
+// ParseRangeWith it understand inputs copied from logs and URLs:
+//
+//	192.0.2.1:8080
+//	[2001:db8::1]:443/64
+//	https://[2001:db8::1]/
+//	168.192.in-addr.arpa
+//
+// Integer address is IPv4, unless opts.IntIPv6 is set. Returned
+// Normalization report what was stripped from the input.
+func Parse(s string, opts ParseOptions) ([]IP, Normalization, error) {
+	s = strings.TrimSpace(s)
+	if IsReverseName(s) {
+		ip, err := ParseReverseName(s)
+		if err != nil {
+			return nil, NormReverse, err
+		}
+		return []IP{ip}, NormReverse, nil
+	}
+
+	s, norm, err := normalize(s)
+	if err != nil {
+		return nil, norm, err
+	}
+
+	// range, only '-' between two addresses is a separator, zone may
+	// contain '-' (fe80::1%br-lan) and "-d" is a misplaced flag
+	if rangeSep(s, opts) > 0 {
+		ips, err := ParseRangeWith(s, opts)
+		return ips, norm, err
+	}
+
+	var ip IP
+	if isIPv6(s, opts) {
+		ip, err = ParseIPv6Prefix(s)
+	} else {
+		ip, err = ParseIPv4PrefixWith(s, opts)
+	}
+	if err != nil {
+		return nil, norm, err
+	}
+	return []IP{ip}, norm, nil
+}
+
+// isIPv6 report if s is IPv6 address: it has ':' or it is an integer
+// address and opts.IntIPv6 is set.
+func isIPv6(s string, opts ParseOptions) bool {
+	if strings.Contains(s, ":") {
+		return true
+	}
+	addr, _, _ := strings.Cut(s, "/")
+	return opts.IntIPv6 && isIntAddr(addr) && !isBinaryIPv4(addr)
+}
+
+// isIntAddr report if s is decimal or hex (0x prefix) integer.
+func isIntAddr(s string) bool {
+	digits := "0123456789"
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, digits = s[2:], "0123456789abcdefABCDEF"
+	}
+	return s != "" && strings.Trim(s, digits) == ""
+}
+
+// normalize remove URL scheme, brackets and port from s.
+func normalize(s string) (string, Normalization, error) {
+	var norm Normalization
+
+	// scheme://user@host:port/path?query
+	if _, rest, ok := strings.Cut(s, "://"); ok {
+		norm |= NormScheme
+		if i := strings.IndexAny(rest, "/?#"); i != -1 {
+			rest = rest[:i]
+		}
+		if i := strings.LastIndex(rest, "@"); i != -1 {
+			rest = rest[i+1:]
+		}
+		// zone in URL is written as %25 (RFC 6874)
+		s = strings.Replace(rest, "%25", "%", 1)
+	}
+
+	// [ipv6]:port/plen
+	if strings.HasPrefix(s, "[") {
+		end := strings.Index(s, "]")
+		if end == -1 {
+			return s, norm, fmt.Errorf("invalid addr, missing ']': %s", s)
+		}
+		norm |= NormBrackets
+		addr, rest := s[1:end], s[end+1:]
+		if strings.HasPrefix(rest, ":") {
+			port, pfx, _ := strings.Cut(rest[1:], "/")
+			if err := checkPort(port); err != nil {
+				return s, norm, err
+			}
+			norm |= NormPort
+			rest = ""
+			if pfx != "" {
+				rest = "/" + pfx
+			}
+		}
+		return addr + rest, norm, nil
+	}
+
+	// ipv4:port, IPv6 always has at least two ':'
+	addr, pfx, hasPfx := strings.Cut(s, "/")
+	if strings.Count(addr, ":") == 1 {
+		host, port, _ := strings.Cut(addr, ":")
+		if err := checkPort(port); err != nil {
+			return s, norm, err
+		}
+		norm |= NormPort
+		s = host
+		if hasPfx {
+			s += "/" + pfx
+		}
+	}
+	return s, norm, nil
+}
+
+// checkPort validate TCP/UDP port number.
+func checkPort(p string) error {
+	if _, err := strconv.ParseUint(p, 10, 16); err != nil {
+		return fmt.Errorf("invalid port: %q", p)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseRange parse "first-last" address range, e.g. 10.0.0.5-10.0.0.200
+// or 2001:db8::10-2001:db8::1ff, and return the smallest list of prefixes
+// that covers exactly the range.
+func ParseRange(s string) ([]IP, error) {
+	return ParseRangeWith(s, ParseOptions{})
+}
+
+// ParseRangeWith work as ParseRange, but the addresses are read according
+// to given options. Link-local range may have a zone, the same on both
+// ends (fe80::1%eth0-fe80::ff%eth0), which is kept in every prefix.
+func ParseRangeWith(s string, opts ParseOptions) ([]IP, error) {
+	i := rangeSep(s, opts)
+	if i < 0 {
+		i = strings.Index(s, "-")
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("invalid range, expected <first>-<last>, given: %s", s)
+	}
+	firstStr := strings.TrimSpace(s[:i])
+	lastStr := strings.TrimSpace(s[i+1:])
+
+	firstStr, zone, _ := strings.Cut(firstStr, "%")
+	lastStr, lastZone, _ := strings.Cut(lastStr, "%")
+	if zone != lastZone {
+		return nil, fmt.Errorf("invalid range, zone %q and %q differ: %s", zone, lastZone, s)
+	}
+	if zone != "" && !validZone(zone) {
+		return nil, fmt.Errorf("invalid range, wrong zone %q: %s", zone, s)
+	}
+
+	first, err := parseRangeEnd(firstStr, opts)
+	if err != nil {
+		return nil, err
+	}
+	last, err := parseRangeEnd(lastStr, opts)
+	if err != nil {
+		return nil, err
+	}
+	if zone != "" && len(first) != 8 {
+		return nil, fmt.Errorf("invalid range, zone in IPv4 range: %s", s)
+	}
+	if len(first) != len(last) {
+		return nil, fmt.Errorf("invalid range, mixed IPv4 and IPv6: %s", s)
+	}
+
+	lo, hi := addrToBig(first), addrToBig(last)
+	if lo.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("invalid range, first address after last: %s", s)
+	}
+
+	ips := rangeToPrefixes(lo, hi, len(first))
+	for i := range ips {
+		ips[i].Zone = zone
+	}
+	return ips, nil
+}
+
+// rangeSep return index of '-' separating range ends, the first one
+// followed by an address, so '-' in zone (fe80::1%br-lan) is skipped.
+// Return -1 when there is none.
+func rangeSep(s string, opts ParseOptions) int {
+	for i, c := range s {
+		if c != '-' {
+			continue
+		}
+		last, _, _ := strings.Cut(strings.TrimSpace(s[i+1:]), "%")
+		if _, err := parseRangeEnd(last, opts); err == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseRangeEnd parse single address of the range, prefix and zone are
+// not allowed.
+func parseRangeEnd(s string, opts ParseOptions) ([]uint16, error) {
+	if s == "" || strings.ContainsAny(s, "/% ") {
+		return nil, fmt.Errorf("invalid range address: %q", s)
+	}
+
+	var (
+		ip  IP
+		err error
+	)
+	if isIPv6(s, opts) {
+		ip, err = ParseIPv6Prefix(s)
+	} else {
+		ip, err = ParseIPv4PrefixWith(s, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ip.Addr, nil
+}
+
+// rangeToPrefixes split address range lo-hi (inclusive) into the minimal
+// list of aligned prefixes. n is number of hextets of the address.
+func rangeToPrefixes(lo, hi *big.Int, n int) []IP {
+	totalBits := uint(n * 16)
+	r := []IP{}
+
+	cur := new(big.Int).Set(lo)
+	for cur.Cmp(hi) <= 0 {
+		// the largest block aligned on cur
+		size := totalBits
+		if cur.Sign() != 0 {
+			size = cur.TrailingZeroBits()
+		}
+		// shrink block until it ends inside the range
+		for size > 0 {
+			end := new(big.Int).Lsh(big.NewInt(1), size)
+			end.Add(end, cur).Sub(end, big.NewInt(1))
+			if end.Cmp(hi) <= 0 {
+				break
+			}
+			size--
+		}
+
+		r = append(r, newIP(bigToAddr(cur, n), uint8(totalBits-size)))
+		cur.Add(cur, new(big.Int).Lsh(big.NewInt(1), size))
+	}
+	return r
+}
@@ -0,0 +1,80 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesRange = []struct {
+	input string
+	exp   []string
+}{
+	// valid
+	{"10.0.0.5-10.0.0.200", []string{
+		"10.0.0.5/32",
+		"10.0.0.6/31",
+		"10.0.0.8/29",
+		"10.0.0.16/28",
+		"10.0.0.32/27",
+		"10.0.0.64/26",
+		"10.0.0.128/26",
+		"10.0.0.192/29",
+		"10.0.0.200/32",
+	}},
+	{"10.0.0.0-10.0.0.255", []string{"10.0.0.0/24"}},
+	{"10.0.0.1 - 10.0.0.1", []string{"10.0.0.1/32"}},
+	{"0.0.0.0-255.255.255.255", []string{"0.0.0.0/0"}},
+	{"192.168.0.255-192.168.1.0", []string{"192.168.0.255/32", "192.168.1.0/32"}},
+	{"2001:db8::10-2001:db8::1ff", []string{
+		"2001:db8::10/124",
+		"2001:db8::20/123",
+		"2001:db8::40/122",
+		"2001:db8::80/121",
+		"2001:db8::100/120",
+	}},
+	{"::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+	{"fe80::1%eth0-fe80::3%eth0", []string{"fe80::1%eth0/128", "fe80::2%eth0/127"}},
+	{"fe80::1%br-lan-fe80::2%br-lan", []string{"fe80::1%br-lan/128", "fe80::2%br-lan/128"}},
+
+	// invalid
+	{"10.0.0.200-10.0.0.5", nil},         // reversed
+	{"10.0.0.1-2001:db8::1", nil},        // mixed family
+	{"10.0.0.0/24-10.0.1.0", nil},        // prefix
+	{"10.0.0.1-", nil},                   // no last
+	{"10.0.0.1-10.0.0.256", nil},         // wrong address
+	{"fe80::1%eth0-fe80::2", nil},        // zone only on first
+	{"fe80::1-fe80::2%eth0", nil},        // zone only on last
+	{"fe80::1%eth0-fe80::2%eth1", nil},   // different zones
+	{"10.0.0.1%eth0-10.0.0.2%eth0", nil}, // IPv4 zone
+}
+
+func TestParseRange(t *testing.T) {
+	for _, tt := range testCasesRange {
+		ips, err := ipcalc.ParseRange(tt.input)
+		if tt.exp == nil {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+
+		got := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			got = append(got, ip.GetAddrMask())
+		}
+		if len(got) != len(tt.exp) {
+			t.Errorf("%q got %v, want %v", tt.input, got, tt.exp)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.exp[i] {
+				t.Errorf("%q got %v, want %v", tt.input, got, tt.exp)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import "math/big"
+
+// Relation of two prefixes, see IP.Relation.
+type Relation int
+
+const (
+	// RelationDisjoint prefixes have no common address and don't touch.
+	RelationDisjoint Relation = iota
+	// RelationAdjacent prefixes have no common address, but one start
+	// right after the other.
+	RelationAdjacent
+	// RelationEqual prefixes cover the same addresses.
+	RelationEqual
+	// RelationContains prefix cover the other one and more.
+	RelationContains
+	// RelationWithin prefix is covered by the other one.
+	RelationWithin
+)
+
+// String return relation name, e.g. "contains".
+func (r Relation) String() string {
+	switch r {
+	case RelationAdjacent:
+		return "adjacent"
+	case RelationEqual:
+		return "equal"
+	case RelationContains:
+		return "contains"
+	case RelationWithin:
+		return "within"
+	default:
+		return "disjoint"
+	}
+}
+
+// Relation return relation of ip network to other network. Prefixes of
+// different family or zone are disjoint, as in Summarize zoned prefix is
+// a network of its own link.
+func (ip IP) Relation(other IP) Relation {
+	if len(ip.Addr) != len(other.Addr) || ip.Zone != other.Zone {
+		return RelationDisjoint
+	}
+	a, b := ipSpan(ip), ipSpan(other)
+
+	lo, hi := a.lo.Cmp(b.lo), a.hi.Cmp(b.hi)
+	switch {
+	case lo == 0 && hi == 0:
+		return RelationEqual
+	case lo <= 0 && hi >= 0:
+		return RelationContains
+	case lo >= 0 && hi <= 0:
+		return RelationWithin
+	}
+
+	one := big.NewInt(1)
+	if new(big.Int).Add(a.hi, one).Cmp(b.lo) == 0 || new(big.Int).Add(b.hi, one).Cmp(a.lo) == 0 {
+		return RelationAdjacent
+	}
+	return RelationDisjoint
+}
+
+// Contains reports whether every address of other network is in ip
+// network.
+func (ip IP) Contains(other IP) bool {
+	r := ip.Relation(other)
+	return r == RelationEqual || r == RelationContains
+}
+
+// Overlaps reports whether ip and other network have a common address.
+func (ip IP) Overlaps(other IP) bool {
+	switch ip.Relation(other) {
+	case RelationEqual, RelationContains, RelationWithin:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesRelation = []struct {
+	a, b        string
+	expRelation ipcalc.Relation
+	expContains bool
+	expOverlaps bool
+}{
+	{"10.0.0.0/8", "10.1.2.3", ipcalc.RelationContains, true, true},
+	{"10.0.0.0/8", "10.1.2.0/24", ipcalc.RelationContains, true, true},
+	{"10.1.2.0/24", "10.0.0.0/8", ipcalc.RelationWithin, false, true},
+	{"10.0.0.0/8", "10.0.0.0/8", ipcalc.RelationEqual, true, true},
+	// host bits don't matter, networks are compared
+	{"10.0.0.1/8", "10.255.0.0/8", ipcalc.RelationEqual, true, true},
+	{"10.0.0.0/24", "10.0.1.0/24", ipcalc.RelationAdjacent, false, false},
+	{"10.0.1.0/24", "10.0.0.0/24", ipcalc.RelationAdjacent, false, false},
+	{"10.0.0.0/24", "10.0.2.0/24", ipcalc.RelationDisjoint, false, false},
+	{"10.0.0.0/8", "11.0.0.0", ipcalc.RelationAdjacent, false, false},
+	{"0.0.0.0/0", "255.255.255.255", ipcalc.RelationContains, true, true},
+	{"2001:db8::/32", "2001:db8:1::/48", ipcalc.RelationContains, true, true},
+	{"2001:db8::/32", "2001:db9::/32", ipcalc.RelationAdjacent, false, false},
+	{"2001:db8::/32", "2001:dba::/32", ipcalc.RelationDisjoint, false, false},
+	{"2001:db8::1", "2001:db8::/64", ipcalc.RelationWithin, false, true},
+	{"::/0", "2001:db8::1", ipcalc.RelationContains, true, true},
+	// different family
+	{"0.0.0.0/0", "::/0", ipcalc.RelationDisjoint, false, false},
+	{"::ffff:0:0/96", "10.0.0.1", ipcalc.RelationDisjoint, false, false},
+	// different zone
+	{"fe80::%eth0/64", "fe80::1%eth0", ipcalc.RelationContains, true, true},
+	{"fe80::%eth0/64", "fe80::1%eth1", ipcalc.RelationDisjoint, false, false},
+	{"fe80::%eth0/64", "fe80::1", ipcalc.RelationDisjoint, false, false},
+	{"fe80::/64", "fe80:0:0:1::%eth0/64", ipcalc.RelationDisjoint, false, false},
+}
+
+func TestRelation(t *testing.T) {
+	for _, tt := range testCasesRelation {
+		a, _, err := ipcalc.Parse(tt.a, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Fatalf("%q unexpected error: %v", tt.a, err)
+		}
+		b, _, err := ipcalc.Parse(tt.b, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Fatalf("%q unexpected error: %v", tt.b, err)
+		}
+
+		if r := a[0].Relation(b[0]); r != tt.expRelation {
+			t.Errorf("%q %q relation got %s, want %s", tt.a, tt.b, r, tt.expRelation)
+		}
+		if r := a[0].Contains(b[0]); r != tt.expContains {
+			t.Errorf("%q %q contains got %v, want %v", tt.a, tt.b, r, tt.expContains)
+		}
+		if r := a[0].Overlaps(b[0]); r != tt.expOverlaps {
+			t.Errorf("%q %q overlaps got %v, want %v", tt.a, tt.b, r, tt.expOverlaps)
+		}
+	}
+}
+
+func TestRelationString(t *testing.T) {
+	exp := map[ipcalc.Relation]string{
+		ipcalc.RelationEqual:    "equal",
+		ipcalc.RelationContains: "contains",
+		ipcalc.RelationWithin:   "within",
+		ipcalc.RelationAdjacent: "adjacent",
+		ipcalc.RelationDisjoint: "disjoint",
+	}
+	for r, s := range exp {
+		if r.String() != s {
+			t.Errorf("%d got %q, want %q", r, r.String(), s)
+		}
+	}
+}
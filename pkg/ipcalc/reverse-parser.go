@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	inAddrArpa = "in-addr.arpa"
+	ip6Arpa    = "ip6.arpa"
+)
+
+// IsReverseName reports whether s is in-addr.arpa or ip6.arpa name.
+func IsReverseName(s string) bool {
+	s = strings.ToLower(strings.TrimSuffix(s, "."))
+	return s == inAddrArpa || s == ip6Arpa ||
+		strings.HasSuffix(s, "."+inAddrArpa) || strings.HasSuffix(s, "."+ip6Arpa)
+}
+
+// ParseReverseName convert reverse DNS name to IP prefix covered by it.
+//
+//	1.2.0.192.in-addr.arpa        192.0.2.1/32
+//	168.192.in-addr.arpa          192.168.0.0/16
+//	0/26.2.0.192.in-addr.arpa     192.0.2.0/26 (RFC 2317)
+//	8.b.d.0.1.0.0.2.ip6.arpa      2001:db8::/32
+//
+// Full name give /32 or /128, partial zone give prefix len of the zone.
+func ParseReverseName(s string) (IP, error) {
+	name := strings.ToLower(strings.TrimSuffix(s, "."))
+	switch {
+	case name == inAddrArpa || strings.HasSuffix(name, "."+inAddrArpa):
+		return parseInAddrArpa(strings.TrimSuffix(name, inAddrArpa), s)
+	case name == ip6Arpa || strings.HasSuffix(name, "."+ip6Arpa):
+		return parseIP6Arpa(strings.TrimSuffix(name, ip6Arpa), s)
+	default:
+		return IP{}, fmt.Errorf("invalid reverse name, expected in-addr.arpa or ip6.arpa: %s", s)
+	}
+}
+
+// parseInAddrArpa parse labels before in-addr.arpa, e.g. "1.2.0.192.".
+func parseInAddrArpa(labels string, s string) (IP, error) {
+	var out IP
+
+	var parts []string
+	if labels != "" {
+		parts = strings.Split(strings.TrimSuffix(labels, "."), ".")
+	}
+	if len(parts) > 4 {
+		return out, fmt.Errorf("invalid reverse name, to many labels: %s", s)
+	}
+
+	pfx := uint8(8 * len(parts))
+	// RFC 2317 classless label, e.g. 0/26 or 0-26
+	if len(parts) == 4 {
+		if first, plen, ok := cutClassless(parts[0]); ok {
+			p, err := strconv.ParseUint(plen, 10, 8)
+			if err != nil || p < 25 || p > 32 {
+				return out, fmt.Errorf("invalid reverse name, wrong classless label %q: %s", parts[0], s)
+			}
+			parts[0] = first
+			pfx = uint8(p)
+		}
+	}
+
+	var v uint32
+	for i := len(parts) - 1; i >= 0; i-- {
+		p := parts[i]
+		if len(p) > 1 && p[0] == '0' {
+			return out, fmt.Errorf("invalid reverse name, leading zero in %q: %s", p, s)
+		}
+		o, err := strconv.ParseUint(p, 10, 8)
+		if err != nil {
+			return out, fmt.Errorf("invalid reverse name, wrong octet %q: %s", p, s)
+		}
+		v = v<<8 | uint32(o)
+	}
+	v <<= 32 - 8*uint(len(parts))
+
+	return newIP(u32ToAddr(v), pfx), nil
+}
+
+// cutClassless split RFC 2317 label "0/26" or "0-26".
+func cutClassless(label string) (string, string, bool) {
+	if first, plen, ok := strings.Cut(label, "/"); ok {
+		return first, plen, true
+	}
+	return strings.Cut(label, "-")
+}
+
+// parseIP6Arpa parse nibble labels before ip6.arpa, e.g. "8.b.d.0.1.0.0.2.".
+func parseIP6Arpa(labels string, s string) (IP, error) {
+	var out IP
+
+	var parts []string
+	if labels != "" {
+		parts = strings.Split(strings.TrimSuffix(labels, "."), ".")
+	}
+	if len(parts) > 32 {
+		return out, fmt.Errorf("invalid reverse name, to many nibbles: %s", s)
+	}
+
+	addr := make([]uint16, 8)
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 16, 4)
+		if len(p) != 1 || err != nil {
+			return out, fmt.Errorf("invalid reverse name, wrong nibble %q: %s", p, s)
+		}
+		// first label is the last nibble of the prefix
+		pos := len(parts) - 1 - i
+		addr[pos/4] |= uint16(n) << (12 - 4*uint(pos%4))
+	}
+
+	return newIP(addr, uint8(4*len(parts))), nil
+}
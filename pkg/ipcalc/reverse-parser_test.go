@@ -0,0 +1,57 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesReverse = []struct {
+	input string
+	exp   string
+}{
+	// valid
+	{"1.2.0.192.in-addr.arpa", "192.0.2.1/32"},
+	{"1.2.0.192.in-addr.arpa.", "192.0.2.1/32"},
+	{"1.2.0.192.IN-ADDR.ARPA", "192.0.2.1/32"},
+	{"168.192.in-addr.arpa", "192.168.0.0/16"},
+	{"10.in-addr.arpa", "10.0.0.0/8"},
+	{"2.0.192.in-addr.arpa", "192.0.2.0/24"},
+	{"in-addr.arpa", "0.0.0.0/0"},
+	{"0/26.2.0.192.in-addr.arpa", "192.0.2.0/26"},
+	{"64-26.2.0.192.in-addr.arpa", "192.0.2.64/26"},
+	{"8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::/32"},
+	{"8.B.D.0.1.0.0.2.IP6.ARPA.", "2001:db8::/32"},
+	{"0.8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::/36"},
+	{"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::1/128"},
+	{"ip6.arpa", "::/0"},
+
+	// invalid
+	{"1.1.2.0.192.in-addr.arpa", ""},    // to many labels
+	{"256.0.192.in-addr.arpa", ""},      // wrong octet
+	{"01.0.192.in-addr.arpa", ""},       // leading zero
+	{"0/24.2.0.192.in-addr.arpa", ""},   // classless above /24 only
+	{"0/26.0.192.in-addr.arpa", ""},     // classless label in partial zone
+	{"10.in-addr.arpa.example", ""},     // not reverse
+	{"g.ip6.arpa", ""},                  // wrong nibble
+	{"10.8.b.d.0.1.0.0.2.ip6.arpa", ""}, // two digit nibble
+	{"0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa", ""}, // 33 nibbles
+}
+
+func TestParseReverseName(t *testing.T) {
+	for _, tt := range testCasesReverse {
+		ip, err := ipcalc.ParseReverseName(tt.input)
+		if tt.exp == "" {
+			if err == nil {
+				t.Errorf("%q expected error, got %s", tt.input, ip.GetAddrMask())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if r := ip.GetAddrMask(); r != tt.exp {
+			t.Errorf("%q got %v, want %v", tt.input, r, tt.exp)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ReverseZones return reverse DNS zones covering the network of ip.
+//
+//   - IPv4 on octet boundary give one in-addr.arpa zone (2.0.192.in-addr.arpa
+//     for /24), other prefix up to /24 give all zones of the next octet
+//     boundary (four /24 zones for /22), longer prefix give RFC 2317
+//     classless name (0/26.2.0.192.in-addr.arpa). /32 give the PTR name.
+//   - IPv6 give ip6.arpa zones on nibble boundary, one for /48 and four
+//     /52 zones for /50.
+func ReverseZones(ip IP) []string {
+	network := ip.GetFirstAddr()
+
+	if len(network) == 2 {
+		v := uint32(network[0])<<16 | uint32(network[1])
+		if ip.Pfx > 24 && ip.Pfx < 32 {
+			return []string{fmt.Sprintf("%d/%d.%s", v&0xff, ip.Pfx, inAddrName(v, 3))}
+		}
+		return reverseZoneList(network, ip.Pfx, 8, func(a []uint16, labels int) string {
+			return inAddrName(uint32(a[0])<<16|uint32(a[1]), labels)
+		})
+	}
+	return reverseZoneList(network, ip.Pfx, 4, ip6ArpaName)
+}
+
+// reverseZoneList return names of all zones with prefix len rounded up to
+// multiple of step, that cover the network.
+func reverseZoneList(network []uint16, pfx uint8, step int, name func([]uint16, int) string) []string {
+	labels := (int(pfx) + step - 1) / step
+	zonePfx := uint(labels * step)
+	count := 1 << (zonePfx - uint(pfx))
+
+	totalBits := uint(len(network) * 16)
+	size := new(big.Int).Lsh(big.NewInt(1), totalBits-zonePfx)
+	cur := addrToBig(network)
+
+	r := make([]string, 0, count)
+	for range count {
+		r = append(r, name(bigToAddr(cur, len(network)), labels))
+		cur.Add(cur, size)
+	}
+	return r
+}
+
+// inAddrName return in-addr.arpa name of the first labels octets of v.
+func inAddrName(v uint32, labels int) string {
+	parts := make([]string, 0, labels+1)
+	for i := labels - 1; i >= 0; i-- {
+		parts = append(parts, fmt.Sprintf("%d", byte(v>>(24-8*i))))
+	}
+	parts = append(parts, inAddrArpa)
+	return strings.Join(parts, ".")
+}
+
+// ip6ArpaName return ip6.arpa name of the first labels nibbles of addr.
+func ip6ArpaName(addr []uint16, labels int) string {
+	parts := make([]string, 0, labels+1)
+	for i := labels - 1; i >= 0; i-- {
+		n := (addr[i/4] >> (12 - 4*uint(i%4))) & 0xf
+		parts = append(parts, fmt.Sprintf("%x", n))
+	}
+	parts = append(parts, ip6Arpa)
+	return strings.Join(parts, ".")
+}
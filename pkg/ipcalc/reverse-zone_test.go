@@ -0,0 +1,81 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesReverseZones = []struct {
+	input string
+	exp   []string
+}{
+	// IPv4
+	{"192.0.2.1/24", []string{"2.0.192.in-addr.arpa"}},
+	{"192.168.5.1/16", []string{"168.192.in-addr.arpa"}},
+	{"10.1.2.3/8", []string{"10.in-addr.arpa"}},
+	{"10.1.2.3/0", []string{"in-addr.arpa"}},
+	{"192.0.2.1/32", []string{"1.2.0.192.in-addr.arpa"}},
+	{"192.0.2.1/26", []string{"0/26.2.0.192.in-addr.arpa"}},
+	{"192.0.2.200/27", []string{"192/27.2.0.192.in-addr.arpa"}},
+	{"192.0.2.1/31", []string{"0/31.2.0.192.in-addr.arpa"}},
+	{"192.0.4.1/22", []string{
+		"4.0.192.in-addr.arpa",
+		"5.0.192.in-addr.arpa",
+		"6.0.192.in-addr.arpa",
+		"7.0.192.in-addr.arpa",
+	}},
+	{"10.0.0.0/15", []string{"0.10.in-addr.arpa", "1.10.in-addr.arpa"}},
+
+	// IPv6
+	{"2001:db8::/32", []string{"8.b.d.0.1.0.0.2.ip6.arpa"}},
+	{"2001:db8:1::/48", []string{"1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"}},
+	{"2001:db8:1::/50", []string{
+		"0.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		"1.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		"2.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		"3.1.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	}},
+	{"2001:db8::/31", []string{"8.b.d.0.1.0.0.2.ip6.arpa", "9.b.d.0.1.0.0.2.ip6.arpa"}},
+	{"::/0", []string{"ip6.arpa"}},
+	{"2001:db8::1/128", []string{
+		"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	}},
+}
+
+func TestReverseZones(t *testing.T) {
+	for _, tt := range testCasesReverseZones {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		got := ipcalc.ReverseZones(ips[0])
+		if len(got) != len(tt.exp) {
+			t.Errorf("%q got %v, want %v", tt.input, got, tt.exp)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.exp[i] {
+				t.Errorf("%q got %v, want %v", tt.input, got, tt.exp)
+				break
+			}
+		}
+	}
+}
+
+func TestReverseZonesRoundTrip(t *testing.T) {
+	for _, tt := range testCasesReverseZones {
+		if len(tt.exp) != 1 {
+			continue
+		}
+		ips, _, _ := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		ip, err := ipcalc.ParseReverseName(tt.exp[0])
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.exp[0], err)
+			continue
+		}
+		if ip.Pfx != ips[0].Pfx {
+			t.Errorf("%q prefix got %d, want %d", tt.exp[0], ip.Pfx, ips[0].Pfx)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+)
+
+// SplitCount return number of subnets with pfx prefix len in ip.
+func SplitCount(ip IP, pfx uint8) (*big.Int, error) {
+	if err := checkSplit(ip, pfx); err != nil {
+		return nil, err
+	}
+	return new(big.Int).Lsh(big.NewInt(1), uint(pfx-ip.Pfx)), nil
+}
+
+// Split divide ip into equal subnets with pfx prefix len. Subnets are
+// yielded in order, starting from the offset one (nil is the first),
+// so very large IPv6 splits can be paged without counting them all.
+// Offset must be below the number of subnets.
+func Split(ip IP, pfx uint8, offset *big.Int) (iter.Seq[IP], error) {
+	count, err := SplitCount(ip, pfx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(ip.Addr)
+	size := new(big.Int).Lsh(big.NewInt(1), uint(n*16)-uint(pfx))
+	start := addrToBig(ip.GetFirstAddr())
+	first := new(big.Int)
+	if offset != nil {
+		if offset.Sign() < 0 {
+			return nil, fmt.Errorf("negative offset %s", offset)
+		}
+		if offset.Cmp(count) >= 0 {
+			return nil, fmt.Errorf("offset %s beyond %s subnets", offset, count)
+		}
+		first.Set(offset)
+	}
+
+	return func(yield func(IP) bool) {
+		v := new(big.Int)
+		for i := new(big.Int).Set(first); i.Cmp(count) < 0; i.Add(i, big.NewInt(1)) {
+			v.Mul(i, size).Add(v, start)
+			sub := newIP(bigToAddr(v, n), pfx)
+			sub.Zone = ip.Zone
+			if !yield(sub) {
+				return
+			}
+		}
+	}, nil
+}
+
+// checkSplit check if pfx is a valid new prefix len for ip.
+func checkSplit(ip IP, pfx uint8) error {
+	bits := uint8(len(ip.Addr) * 16)
+	if pfx < ip.Pfx || pfx > bits {
+		return fmt.Errorf("new prefix len /%d must be between /%d and /%d", pfx, ip.Pfx, bits)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"math/big"
+	"testing"
+)
+
+var testCasesSplit = []struct {
+	input    string
+	pfx      uint8
+	offset   int64
+	expCount string
+	expFirst []string // first subnets yielded
+	expErr   bool
+}{
+	{"10.0.0.0/16", 24, 0, "256", []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24"}, false},
+	{"10.0.0.0/16", 24, 254, "256", []string{"10.0.254.0/24", "10.0.255.0/24"}, false},
+	{"192.168.1.77/24", 26, 0, "4", []string{"192.168.1.0/26", "192.168.1.64/26", "192.168.1.128/26", "192.168.1.192/26"}, false},
+	{"192.168.1.1/24", 24, 0, "1", []string{"192.168.1.0/24"}, false},
+	{"0.0.0.0/0", 32, 4294967295, "4294967296", []string{"255.255.255.255/32"}, false},
+	{"2001:db8::/32", 48, 0, "65536", []string{"2001:db8::/48", "2001:db8:1::/48"}, false},
+	{"2001:db8::/32", 48, 65535, "65536", []string{"2001:db8:ffff::/48"}, false},
+	{"2001:db8::/32", 64, 0, "4294967296", []string{"2001:db8::/64", "2001:db8:0:1::/64"}, false},
+	{"::/0", 128, 0, "340282366920938463463374607431768211456", []string{"::/128", "::1/128"}, false},
+	{"fe80::1%eth0/64", 65, 1, "2", []string{"fe80::8000:0:0:0%eth0/65"}, false},
+
+	{"10.0.0.0/16", 8, 0, "", nil, true},
+	{"10.0.0.0/16", 33, 0, "", nil, true},
+	{"2001:db8::/32", 129, 0, "", nil, true},
+	{"10.0.0.0/16", 24, -1, "", nil, true},
+	{"10.0.0.0/16", 24, 256, "", nil, true}, // offset beyond subnets
+	{"10.0.0.0/16", 24, 300, "", nil, true},
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range testCasesSplit {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		seq, err := ipcalc.Split(ips[0], tt.pfx, big.NewInt(tt.offset))
+		if (err != nil) != tt.expErr {
+			t.Errorf("%q /%d error got %v, want error %v", tt.input, tt.pfx, err, tt.expErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		count, _ := ipcalc.SplitCount(ips[0], tt.pfx)
+		if count.String() != tt.expCount {
+			t.Errorf("%q /%d count got %s, want %s", tt.input, tt.pfx, count, tt.expCount)
+		}
+
+		var got []string
+		for sub := range seq {
+			if len(got) == len(tt.expFirst) {
+				break
+			}
+			got = append(got, sub.GetAddrMask())
+		}
+		if len(got) != len(tt.expFirst) {
+			t.Errorf("%q /%d got %v, want %v", tt.input, tt.pfx, got, tt.expFirst)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expFirst[i] {
+				t.Errorf("%q /%d subnet %d got %q, want %q", tt.input, tt.pfx, i, got[i], tt.expFirst[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"math/big"
+	"slices"
+)
+
+// span is inclusive address range lo-hi of one family.
+type span struct {
+	lo, hi *big.Int
+}
+
+// Summarize aggregate ips into the minimal list of prefixes covering
+// exactly the same addresses. Addresses are normalized to networks,
+// contained and duplicate prefixes are removed and adjacent ones merged.
+// IPv4 prefixes are returned first, then IPv6, each sorted by address.
+// Zoned IPv6 prefixes (fe80::%eth0/64) are summarized per zone, as the
+// same link-local prefix on other interface is a different network.
+// They follow prefixes without zone, ordered by zone name.
+func Summarize(ips []IP) []IP {
+	r := []IP{}
+	for _, n := range []int{2, 8} {
+		var zones []string
+		for _, ip := range ips {
+			if len(ip.Addr) == n {
+				zones = append(zones, ip.Zone)
+			}
+		}
+		slices.Sort(zones)
+		for _, zone := range slices.Compact(zones) {
+			var spans []span
+			for _, ip := range ips {
+				if len(ip.Addr) == n && ip.Zone == zone {
+					spans = append(spans, ipSpan(ip))
+				}
+			}
+			for _, s := range mergeSpans(spans) {
+				for _, ip := range rangeToPrefixes(s.lo, s.hi, n) {
+					ip.Zone = zone
+					r = append(r, ip)
+				}
+			}
+		}
+	}
+	return r
+}
+
+// ipSpan return address range of the ip network.
+func ipSpan(ip IP) span {
+	return span{addrToBig(ip.GetFirstAddr()), addrToBig(ip.GetLastAddr())}
+}
+
+// mergeSpans sort spans and merge overlapping and adjacent ones.
+func mergeSpans(spans []span) []span {
+	slices.SortFunc(spans, func(a, b span) int {
+		return a.lo.Cmp(b.lo)
+	})
+
+	var r []span
+	next := new(big.Int)
+	for _, s := range spans {
+		if len(r) > 0 {
+			last := &r[len(r)-1]
+			// s starts inside or right after the last one
+			if next.Add(last.hi, big.NewInt(1)).Cmp(s.lo) >= 0 {
+				if s.hi.Cmp(last.hi) > 0 {
+					last.hi = s.hi
+				}
+				continue
+			}
+		}
+		r = append(r, s)
+	}
+	return r
+}
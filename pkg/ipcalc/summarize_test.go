@@ -0,0 +1,60 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"strings"
+	"testing"
+)
+
+var testCasesSummarize = []struct {
+	input string
+	exp   string
+}{
+	{"10.0.0.0/24 10.0.1.0/24", "10.0.0.0/23"},
+	{"10.0.1.0/24 10.0.0.0/24 10.0.2.0/24 10.0.3.0/24", "10.0.0.0/22"},
+	// not siblings, 10.0.1.0/24 and 10.0.2.0/24 can't be one prefix
+	{"10.0.1.0/24 10.0.2.0/24", "10.0.1.0/24 10.0.2.0/24"},
+	// hosts are normalized to network
+	{"10.0.0.1/24 10.0.1.77/24", "10.0.0.0/23"},
+	{"192.168.0.0/16 192.168.1.0/24 192.168.1.0/24", "192.168.0.0/16"},
+	{"192.168.1.0/24 192.168.1.0/24", "192.168.1.0/24"},
+	{"10.0.0.0/25 10.0.0.128/26 10.0.0.192/26 10.0.1.0/24", "10.0.0.0/23"},
+	{"10.0.0.0/25 10.0.0.192/26", "10.0.0.0/25 10.0.0.192/26"},
+	{"10.0.0.0/32 10.0.0.1/32 10.0.0.2/32", "10.0.0.0/31 10.0.0.2/32"},
+	// overlapping without containment
+	{"10.0.0.0-10.0.0.5 10.0.0.4-10.0.0.7", "10.0.0.0/29"},
+	{"0.0.0.0/1 128.0.0.0/1", "0.0.0.0/0"},
+	{"255.255.255.254/32 255.255.255.255/32", "255.255.255.254/31"},
+	{
+		"2001:db8::/48 2001:db8:1::/48 10.0.0.0/24 2001:db8:2::/47 10.0.1.0/24",
+		"10.0.0.0/23 2001:db8::/46",
+	},
+	{"2001:db8::/33 2001:db8:8000::/33", "2001:db8::/32"},
+	{"::/1 8000::/1", "::/0"},
+	// zones are summarized separately
+	{"fe80::1%eth0/64 fe80:0:0:1::/64", "fe80:0:0:1::/64 fe80::%eth0/64"},
+	{"fe80::%eth0/64 fe80:0:0:1::%eth0/64", "fe80::%eth0/63"},
+	{"fe80::%eth1/64 fe80::%eth0/64 fe80:0:0:1::%eth1/64", "fe80::%eth0/64 fe80::%eth1/63"},
+	{"", ""},
+}
+
+func TestSummarize(t *testing.T) {
+	for _, tt := range testCasesSummarize {
+		var ips []ipcalc.IP
+		for _, s := range strings.Fields(tt.input) {
+			r, _, err := ipcalc.Parse(s, ipcalc.ParseOptions{})
+			if err != nil {
+				t.Fatalf("%q unexpected error: %v", s, err)
+			}
+			ips = append(ips, r...)
+		}
+
+		var got []string
+		for _, ip := range ipcalc.Summarize(ips) {
+			got = append(got, ip.GetAddrMask())
+		}
+		if r := strings.Join(got, " "); r != tt.exp {
+			t.Errorf("%q got %q, want %q", tt.input, r, tt.exp)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package ipcalc
 import (
 	"fmt"
 	"math/big"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -16,9 +17,57 @@ type IP struct {
 	Addr []uint16
 	Mask []uint16
 	Pfx  uint8
+	// Zone of IPv6 scoped address (eth0 in fe80::1%eth0), empty if none.
+	// It is not a part of network and last address calculation.
+	Zone string
+	// Dotted is set when IPv6 address was given with dotted IPv4 tail
+	// (2001:db8::10.0.0.1), Format.Mixed print it back in the same way.
+	Dotted bool
 }
 
-func (ip IP) Pretty(detail bool, pretty bool) [][2]string {
+// ParseOptions tune how the parsers read the input.
+type ParseOptions struct {
+	// Classful infer IPv4 prefix length from the address class
+	// (10.x -> /8, 172.16 -> /16, 192.168 -> /24) when no mask is given,
+	// instead of using host route /32.
+	Classful bool
+	// Lenient read IPv4 address with inet_aton(3) rules: short forms
+	// (a, a.b, a.b.c) and hex (0x) or octal (0) parts. By default only
+	// four decimal octets without leading zeros are accepted.
+	Lenient bool
+	// IntIPv6 read integer address (42540766411282592856903984951653826561,
+	// 0x20010db8000000000000000000000001) as 128-bit IPv6. By default
+	// integer address is 32-bit IPv4, family is never guessed from the
+	// value or prefix length.
+	IntIPv6 bool
+}
+
+// Format control how Pretty and Format.Addr render the address.
+// Zero value is the default output.
+type Format struct {
+	// Detail add mask, hosts number and other detail rows.
+	Detail bool
+	// Pretty group digits of big numbers with spaces.
+	Pretty bool
+	// Mixed print last 32 bits of IPv6 address with embedded IPv4 as
+	// dotted IPv4: IPv4-mapped ::ffff:0:0/96 (::ffff:192.0.2.1), NAT64
+	// 64:ff9b::/96 and IP given with dotted tail (see IP.Dotted).
+	Mixed bool
+	// Expanded print IPv6 address with all 8 hextets and leading zeros
+	// instead of RFC 5952 canonical form.
+	Expanded bool
+	// Binary add address, mask, network and last address in binary with
+	// network/host bit split.
+	Binary bool
+
+	// dotted print every IPv6 address mixed when Mixed is set, used for
+	// IP.Dotted.
+	dotted bool
+}
+
+// Pretty return list of row name and value describing the IP.
+func (ip IP) Pretty(f Format) [][2]string {
+	f.dotted = ip.Dotted
 	// setup name for last address
 	var tagLast string
 	if len(ip.Addr) == 2 {
@@ -28,25 +77,69 @@ func (ip IP) Pretty(detail bool, pretty bool) [][2]string {
 	}
 
 	result := [][2]string{
-		{"Full address", ip.GetAddrMask()},
-		{"Network", NiceAddr(ip.GetFirstAddr())},
-		{tagLast, NiceAddr(ip.GetLastAddr())},
+		{"Full address", f.AddrMask(ip)},
+		{"Network", f.Addr(ip.GetFirstAddr())},
+		{tagLast, f.Addr(ip.GetLastAddr())},
 	}
 
-	if detail {
+	if f.Detail {
 		tmp := [][2]string{
-			{"Address", NiceAddr(ip.Addr)},
+			{"Address", f.zoned(ip.Addr, ip.Zone)},
+			{"Type", Classify(ip).String()},
 			{"Mask", strconv.Itoa(int(ip.Pfx))},
-			{"Mask address", NiceAddr(ip.Mask)},
-			{"Hosts number", ip.GetHostsNumberStr(pretty)},
+			{"Mask address", Format{Expanded: f.Expanded}.Addr(ip.Mask)},
+			{"Wildcard mask", Format{Expanded: f.Expanded}.Addr(ip.GetWildcardMask())},
+			{"HostMin", f.Addr(ip.GetHostMin())},
+			{"HostMax", f.Addr(ip.GetHostMax())},
+			{"Total addresses", ip.GetHostsNumberStr(f.Pretty)},
+			{"Usable hosts", ip.GetUsableHostsStr(f.Pretty)},
+			{"Address integer", AddrInt(ip.Addr).String()},
+			{"Address hex", AddrHex(ip.Addr)},
+			{"Network integer", AddrInt(ip.GetFirstAddr()).String()},
+			{"Network hex", AddrHex(ip.GetFirstAddr())},
+			{tagLast + " integer", AddrInt(ip.GetLastAddr()).String()},
+			{tagLast + " hex", AddrHex(ip.GetLastAddr())},
+		}
+		result = append(result, tmp...)
+		if c, ok := ClassfulInfo(ip); ok {
+			result = append(result, c.rows(f)...)
+		}
+		if m, ok := DecodeMulticast(ip); ok {
+			result = append(result, m.rows(f)...)
+		}
+		if mac, ok := MulticastMAC(ip); ok {
+			result = append(result, [2]string{"Multicast MAC", mac})
+			for _, o := range MACOverlap(ip) {
+				result = append(result, [2]string{"MAC overlap", f.Addr(o)})
+			}
+		}
+		for _, z := range ReverseZones(ip) {
+			result = append(result, [2]string{"Reverse zone", z})
+		}
+	}
+
+	if f.Binary {
+		tmp := [][2]string{
+			{"Binary address", BinaryAddr(ip.Addr, ip.Pfx)},
+			{"Binary mask", BinaryAddr(ip.Mask, ip.Pfx)},
+			{"Binary network", BinaryAddr(ip.GetFirstAddr(), ip.Pfx)},
+			{"Binary " + strings.ToLower(tagLast), BinaryAddr(ip.GetLastAddr(), ip.Pfx)},
+			{"Binary ruler", BinaryRuler(ip)},
 		}
 		result = append(result, tmp...)
 	}
 	return result
 }
 
-// NiceAddr format ip.Addr [][uint16 to string ipv4/6 address string
+// NiceAddr format ip.Addr []uint16 to string ipv4/6 address string
 func NiceAddr(ip []uint16) string {
+	return Format{}.Addr(ip)
+}
+
+// Addr format ip.Addr []uint16 to ipv4/6 address string according to f.
+// IPv6 is written in RFC 5952 canonical form (2001:db8::1), unless
+// f.Expanded is set (2001:0db8:0000:0000:0000:0000:0000:0001).
+func (f Format) Addr(ip []uint16) string {
 	switch len(ip) {
 	case 2:
 		return fmt.Sprintf("%d.%d.%d.%d",
@@ -56,23 +149,91 @@ func NiceAddr(ip []uint16) string {
 			byte(ip[1]&0x00ff),
 		)
 	case 8:
-		return fmt.Sprintf("%x:%x:%x:%x:%x:%x:%x:%x",
-			ip[0],
-			ip[1],
-			ip[2],
-			ip[3],
-			ip[4],
-			ip[5],
-			ip[6],
-			ip[7],
-		)
+		mixed := f.Mixed && (f.dotted || hasIPv4Tail(ip))
+		hextets := ip
+		if mixed {
+			hextets = ip[:6]
+		}
+
+		var r string
+		if f.Expanded {
+			parts := make([]string, len(hextets))
+			for i, h := range hextets {
+				parts[i] = fmt.Sprintf("%04x", h)
+			}
+			r = strings.Join(parts, ":")
+		} else {
+			r = compressHextets(hextets)
+		}
+
+		if mixed {
+			// "::" already end with separator
+			if !strings.HasSuffix(r, "::") {
+				r += ":"
+			}
+			r += Format{}.Addr(ip[6:])
+		}
+		return r
 	default:
 		return ""
 	}
 }
 
+// hasIPv4Tail reports whether IPv6 address has embedded IPv4 in the
+// last 32 bits, IPv4-mapped ::ffff:0:0/96 or NAT64 64:ff9b::/96.
+func hasIPv4Tail(ip []uint16) bool {
+	mapped := []uint16{0, 0, 0, 0, 0, 0xffff}
+	nat64 := []uint16{0x64, 0xff9b, 0, 0, 0, 0}
+	return slices.Equal(ip[:6], mapped) || slices.Equal(ip[:6], nat64)
+}
+
+// compressHextets join hextets with ':' and replace the longest run of
+// at least two zero hextets with '::', the first one on tie (RFC 5952).
+func compressHextets(h []uint16) string {
+	best, bestLen := -1, 1
+	for i := 0; i < len(h); {
+		if h[i] != 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(h) && h[j] == 0 {
+			j++
+		}
+		if j-i > bestLen {
+			best, bestLen = i, j-i
+		}
+		i = j
+	}
+
+	parts := make([]string, len(h))
+	for i, v := range h {
+		parts[i] = fmt.Sprintf("%x", v)
+	}
+	if best == -1 {
+		return strings.Join(parts, ":")
+	}
+	return strings.Join(parts[:best], ":") + "::" + strings.Join(parts[best+bestLen:], ":")
+}
+
+// GetAddrMask return address with prefix len, e.g. 10.0.0.1/24.
 func (ip IP) GetAddrMask() string {
-	return fmt.Sprintf("%s/%d", NiceAddr(ip.Addr), ip.Pfx)
+	return Format{}.AddrMask(ip)
+}
+
+// AddrMask return address with zone and prefix len formatted
+// according to f.
+func (f Format) AddrMask(ip IP) string {
+	f.dotted = ip.Dotted
+	return fmt.Sprintf("%s/%d", f.zoned(ip.Addr, ip.Zone), ip.Pfx)
+}
+
+// zoned format address and append zone if any, e.g. fe80::1%eth0.
+func (f Format) zoned(ip []uint16, zone string) string {
+	if zone == "" {
+		return f.Addr(ip)
+	}
+	return f.Addr(ip) + "%" + zone
 }
 
 // GetFirstAddr return string with calcualted network address
@@ -165,6 +326,16 @@ func (ip IP) GetLastAddr() []uint16 {
 	return r
 }
 
+// GetWildcardMask return inverse of the mask, as used by ACL and OSPF
+// network statements, e.g. 0.0.0.255 for /24.
+func (ip IP) GetWildcardMask() []uint16 {
+	r := make([]uint16, len(ip.Mask))
+	for i, m := range ip.Mask {
+		r[i] = ^m
+	}
+	return r
+}
+
 // formatBigIntWithSpaces sperate big int value on space sparate string
 func formatBigIntWithSpaces(n *big.Int) string {
 	s := n.String()
@@ -183,28 +354,129 @@ func formatBigIntWithSpaces(n *big.Int) string {
 	return strings.Join(parts, " ")
 }
 
+// GetHostsNumberStr return total number of addresses in the network,
+// 2^(host bits), including network, broadcast and anycast addresses.
 func (ip IP) GetHostsNumberStr(format bool) string {
-	var totalBits uint
-	if len(ip.Addr) == 2 { // IPv4
-		totalBits = 32
-	} else { // IPv6
-		totalBits = 128
+	return formatBigInt(ip.GetHostsNumber(), format)
+}
+
+// GetHostsNumber return total number of addresses in the network.
+func (ip IP) GetHostsNumber() *big.Int {
+	hostBits := uint(len(ip.Addr)*16) - uint(ip.Pfx)
+	// Use big.Int for 2^hostBits
+	return new(big.Int).Lsh(big.NewInt(1), hostBits)
+}
+
+// GetUsableHosts return number of addresses which can be assigned to hosts.
+//
+//   - IPv4 network and broadcast address are not usable, except /31
+//     point-to-point (RFC 3021) and /32 host route.
+//   - IPv6 has no broadcast, but the first address is Subnet-Router anycast,
+//     except /127 point-to-point (RFC 6164) and /128 host route.
+func (ip IP) GetUsableHosts() *big.Int {
+	total := ip.GetHostsNumber()
+	switch ip.hostBits() {
+	case 0, 1:
+		return total
 	}
+	if len(ip.Addr) == 2 {
+		return total.Sub(total, big.NewInt(2))
+	}
+	return total.Sub(total, big.NewInt(1))
+}
+
+// GetUsableHostsStr return GetUsableHosts as string, grouped with spaces
+// when format is true.
+func (ip IP) GetUsableHostsStr(format bool) string {
+	return formatBigInt(ip.GetUsableHosts(), format)
+}
 
-	mask := uint(ip.Pfx)
-	hostBits := totalBits - mask
+// GetHostMin return the first usable host address, see GetUsableHosts.
+func (ip IP) GetHostMin() []uint16 {
+	first := ip.GetFirstAddr()
+	if ip.hostBits() <= 1 {
+		return first
+	}
+	v := addrToBig(first)
+	return bigToAddr(v.Add(v, big.NewInt(1)), len(first))
+}
 
-	// Special case for IPv4 /31 networks
-	if totalBits == 32 && mask == 31 {
-		return "2"
+// GetHostMax return the last usable host address, see GetUsableHosts.
+func (ip IP) GetHostMax() []uint16 {
+	last := ip.GetLastAddr()
+	// only IPv4 loose broadcast address
+	if ip.hostBits() <= 1 || len(ip.Addr) != 2 {
+		return last
 	}
+	v := addrToBig(last)
+	return bigToAddr(v.Sub(v, big.NewInt(1)), len(last))
+}
 
-	// Use big.Int for 2^hostBits
-	result := new(big.Int).Lsh(big.NewInt(1), hostBits)
+// hostBits return number of host bits of the network.
+func (ip IP) hostBits() int {
+	return len(ip.Addr)*16 - int(ip.Pfx)
+}
 
+// formatBigInt return n as string, grouped with spaces when format is true.
+func formatBigInt(n *big.Int, format bool) string {
 	if format {
-		return formatBigIntWithSpaces(result)
-	} else {
-		return result.String()
+		return formatBigIntWithSpaces(n)
+	}
+	return n.String()
+}
+
+// AddrInt return address as integer, 32-bit for IPv4 and 128-bit
+// for IPv6.
+func AddrInt(ip []uint16) *big.Int {
+	return addrToBig(ip)
+}
+
+// AddrHex return address as zero padded hex number, e.g. 0xc0a80101.
+func AddrHex(ip []uint16) string {
+	var b strings.Builder
+	b.WriteString("0x")
+	for _, h := range ip {
+		fmt.Fprintf(&b, "%04x", h)
+	}
+	return b.String()
+}
+
+// addrToBig convert address []uint16 to big.Int.
+func addrToBig(addr []uint16) *big.Int {
+	r := new(big.Int)
+	for _, h := range addr {
+		r.Lsh(r, 16)
+		r.Or(r, big.NewInt(int64(h)))
+	}
+	return r
+}
+
+// bigToAddr convert big.Int to address with n hextets (2 for IPv4,
+// 8 for IPv6). Bits above the address size are dropped.
+func bigToAddr(v *big.Int, n int) []uint16 {
+	r := make([]uint16, n)
+	tmp := new(big.Int).Set(v)
+	word := big.NewInt(0xFFFF)
+	for i := n - 1; i >= 0; i-- {
+		r[i] = uint16(new(big.Int).And(tmp, word).Uint64())
+		tmp.Rsh(tmp, 16)
+	}
+	return r
+}
+
+// maskFor return mask of prefix len for address with n hextets.
+func maskFor(n int, pfx uint8) []uint16 {
+	if n == 2 {
+		return prefixToMaskIPv4(pfx)
+	}
+	return parseMaskHextet(pfx)
+}
+
+// newIP build IP from address and prefix len.
+func newIP(addr []uint16, pfx uint8) IP {
+	return IP{
+		Addr: addr,
+		Mask: maskFor(len(addr), pfx),
+		Pfx:  pfx,
 	}
 }
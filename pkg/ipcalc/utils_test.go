@@ -101,7 +101,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0x0000, 0x0000, 0x0000, 0x0000},
 			Pfx:  64,
 		},
-		"2001:db8:0:0:0:0:0:1",
+		"2001:db8::1",
 		[]uint16{0x2001, 0x0DB8, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 		[]uint16{0x2001, 0x0DB8, 0x0000, 0x0000, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF},
 	},
@@ -112,7 +112,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF},
 			Pfx:  128,
 		},
-		"0:0:0:0:0:0:0:1",
+		"::1",
 		[]uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0001},
 		[]uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0001},
 	},
@@ -123,7 +123,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 			Pfx:  0,
 		},
-		"0:0:0:0:0:0:0:0",
+		"::",
 		[]uint16{0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 		[]uint16{0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF},
 	},
@@ -145,7 +145,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xF800, 0x0000, 0x0000, 0x0000},
 			Pfx:  69,
 		},
-		"2001:0:0:0:0:0:db8:1",
+		"2001::db8:1",
 		[]uint16{0x2001, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 		[]uint16{0x2001, 0x0000, 0x0000, 0x0000, 0x07FF, 0xFFFF, 0xFFFF, 0xFFFF},
 	},
@@ -156,7 +156,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0xE000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 			Pfx:  3,
 		},
-		"2001:db8:0:0:0:0:0:11",
+		"2001:db8::11",
 		[]uint16{0x2000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 		[]uint16{0x3FFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF},
 	},
@@ -167,7 +167,7 @@ var testCasesUtils = []testStruct{
 			Mask: []uint16{0xFFFF, 0xFFFF, 0xE000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 			Pfx:  35,
 		},
-		"201:0:0:0:bd:13:14:15",
+		"201::bd:13:14:15",
 		[]uint16{0x0201, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000},
 		[]uint16{0x0201, 0x0000, 0x1FFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF, 0xFFFF},
 	},
@@ -199,3 +199,198 @@ func TestGetLastAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatAddrMixed(t *testing.T) {
+	tests := []struct {
+		addr []uint16
+		exp  string
+	}{
+		{[]uint16{0, 0, 0, 0, 0, 0xffff, 0xc000, 0x0201}, "::ffff:192.0.2.1"},
+		{[]uint16{0x0064, 0xff9b, 0, 0, 0, 0, 0xc633, 0x6407}, "64:ff9b::198.51.100.7"},
+		{[]uint16{0x0a00, 0x0001}, "10.0.0.1"},
+		// no embedded IPv4
+		{[]uint16{0x2001, 0x0db8, 0, 0, 0, 0, 0, 1}, "2001:db8::1"},
+		{[]uint16{0, 0, 0, 0, 0, 0, 0x0102, 0x0304}, "::102:304"},
+		{[]uint16{0x0064, 0xff9b, 1, 0, 0, 0, 0xc633, 0x6407}, "64:ff9b:1::c633:6407"},
+	}
+	f := ipcalc.Format{Mixed: true}
+	for _, tt := range tests {
+		if r := f.Addr(tt.addr); r != tt.exp {
+			t.Errorf("mixed %x got %v, want %v", tt.addr, r, tt.exp)
+		}
+	}
+}
+
+func TestFormatAddrCanonical(t *testing.T) {
+	tests := []struct {
+		addr        []uint16
+		expCanon    string
+		expExpanded string
+	}{
+		// longest zero run compressed
+		{
+			[]uint16{0x2001, 0x0db8, 0, 0, 1, 0, 0, 0},
+			"2001:db8:0:0:1::",
+			"2001:0db8:0000:0000:0001:0000:0000:0000",
+		},
+		// tie, the first run compressed
+		{
+			[]uint16{0x2001, 0x0db8, 0, 0, 1, 0, 0, 1},
+			"2001:db8::1:0:0:1",
+			"2001:0db8:0000:0000:0001:0000:0000:0001",
+		},
+		// single zero hextet is not compressed
+		{
+			[]uint16{0x2001, 0x0db8, 0, 1, 1, 1, 1, 1},
+			"2001:db8:0:1:1:1:1:1",
+			"2001:0db8:0000:0001:0001:0001:0001:0001",
+		},
+		// lowercase, no leading zeros
+		{
+			[]uint16{0xABCD, 0x00EF, 0, 0, 0, 0, 0, 0x0A},
+			"abcd:ef::a",
+			"abcd:00ef:0000:0000:0000:0000:0000:000a",
+		},
+		{
+			[]uint16{0, 0, 0, 0, 0, 0, 0, 0},
+			"::",
+			"0000:0000:0000:0000:0000:0000:0000:0000",
+		},
+		{
+			[]uint16{1, 0, 0, 0, 0, 0, 0, 0},
+			"1::",
+			"0001:0000:0000:0000:0000:0000:0000:0000",
+		},
+		{
+			[]uint16{0x0a00, 0x0001},
+			"10.0.0.1",
+			"10.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		if r := ipcalc.NiceAddr(tt.addr); r != tt.expCanon {
+			t.Errorf("canonical %x got %v, want %v", tt.addr, r, tt.expCanon)
+		}
+		r := ipcalc.Format{Expanded: true}.Addr(tt.addr)
+		if r != tt.expExpanded {
+			t.Errorf("expanded %x got %v, want %v", tt.addr, r, tt.expExpanded)
+		}
+	}
+
+	mixed := ipcalc.Format{Mixed: true, Expanded: true}
+	exp := "0000:0000:0000:0000:0000:ffff:192.0.2.1"
+	if r := mixed.Addr([]uint16{0, 0, 0, 0, 0, 0xffff, 0xc000, 0x0201}); r != exp {
+		t.Errorf("mixed expanded got %v, want %v", r, exp)
+	}
+	// given with dotted tail
+	ip, err := ipcalc.ParseIPv6Prefix("::1.2.3.4/120")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := (ipcalc.Format{Mixed: true}).AddrMask(ip); r != "::1.2.3.4/120" {
+		t.Errorf("mixed compressed got %v, want ::1.2.3.4/120", r)
+	}
+	if r := ip.GetAddrMask(); r != "::102:304/120" {
+		t.Errorf("not mixed got %v, want ::102:304/120", r)
+	}
+}
+
+func TestAddrIntHex(t *testing.T) {
+	tests := []struct {
+		addr   []uint16
+		expInt string
+		expHex string
+	}{
+		{[]uint16{0xc0a8, 0x0101}, "3232235777", "0xc0a80101"},
+		{[]uint16{0x0000, 0x0000}, "0", "0x00000000"},
+		{[]uint16{0xffff, 0xffff}, "4294967295", "0xffffffff"},
+		{
+			[]uint16{0x2001, 0x0db8, 0, 0, 0, 0, 0, 1},
+			"42540766411282592856903984951653826561",
+			"0x20010db8000000000000000000000001",
+		},
+		{
+			[]uint16{0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff},
+			"340282366920938463463374607431768211455",
+			"0xffffffffffffffffffffffffffffffff",
+		},
+	}
+	for _, tt := range tests {
+		if r := ipcalc.AddrInt(tt.addr).String(); r != tt.expInt {
+			t.Errorf("int %x got %v, want %v", tt.addr, r, tt.expInt)
+		}
+		if r := ipcalc.AddrHex(tt.addr); r != tt.expHex {
+			t.Errorf("hex %x got %v, want %v", tt.addr, r, tt.expHex)
+		}
+	}
+}
+
+func TestGetWildcardMask(t *testing.T) {
+	tests := []struct {
+		input string
+		exp   string
+	}{
+		{"10.0.0.1/24", "0.0.0.255"},
+		{"10.0.0.1/19", "0.0.31.255"},
+		{"10.0.0.1/32", "0.0.0.0"},
+		{"10.0.0.1/0", "255.255.255.255"},
+		{"2001:db8::1/64", "::ffff:ffff:ffff:ffff"},
+		{"2001:db8::1/36", "::fff:ffff:ffff:ffff:ffff:ffff"},
+		{"2001:db8::1/128", "::"},
+	}
+	for _, tt := range tests {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if r := ipcalc.NiceAddr(ips[0].GetWildcardMask()); r != tt.exp {
+			t.Errorf("%q wildcard got %v, want %v", tt.input, r, tt.exp)
+		}
+	}
+}
+
+var testCasesHosts = []struct {
+	input     string
+	expTotal  string
+	expUsable string
+	expMin    string
+	expMax    string
+}{
+	// IPv4
+	{"192.168.1.10/24", "256", "254", "192.168.1.1", "192.168.1.254"},
+	{"192.168.1.10/30", "4", "2", "192.168.1.9", "192.168.1.10"},
+	{"192.168.1.10/31", "2", "2", "192.168.1.10", "192.168.1.11"}, // RFC 3021
+	{"192.168.1.10/32", "1", "1", "192.168.1.10", "192.168.1.10"},
+	{"0.0.0.0/0", "4294967296", "4294967294", "0.0.0.1", "255.255.255.254"},
+
+	// IPv6
+	{"2001:db8::1/64", "18446744073709551616", "18446744073709551615", "2001:db8::1", "2001:db8::ffff:ffff:ffff:ffff"},
+	{"2001:db8::1/126", "4", "3", "2001:db8::1", "2001:db8::3"},
+	{"2001:db8::1/127", "2", "2", "2001:db8::", "2001:db8::1"}, // RFC 6164
+	{"2001:db8::1/128", "1", "1", "2001:db8::1", "2001:db8::1"},
+	{"::/0", "340282366920938463463374607431768211456", "340282366920938463463374607431768211455", "::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+}
+
+func TestHosts(t *testing.T) {
+	for _, tt := range testCasesHosts {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		ip := ips[0]
+		if r := ip.GetHostsNumberStr(false); r != tt.expTotal {
+			t.Errorf("%q total got %v, want %v", tt.input, r, tt.expTotal)
+		}
+		if r := ip.GetUsableHostsStr(false); r != tt.expUsable {
+			t.Errorf("%q usable got %v, want %v", tt.input, r, tt.expUsable)
+		}
+		if r := ipcalc.NiceAddr(ip.GetHostMin()); r != tt.expMin {
+			t.Errorf("%q host min got %v, want %v", tt.input, r, tt.expMin)
+		}
+		if r := ipcalc.NiceAddr(ip.GetHostMax()); r != tt.expMax {
+			t.Errorf("%q host max got %v, want %v", tt.input, r, tt.expMax)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Demand is a named subnet request for VLSM, Hosts is number of usable
+// hosts the subnet must have.
+type Demand struct {
+	Name  string
+	Hosts uint64
+}
+
+// Allocation is a subnet given to a Demand. Allocation with empty Name
+// is free (not allocated) space of the parent.
+type Allocation struct {
+	Demand
+	IP
+}
+
+// VLSMPlan is a result of VLSM, allocations in address order and free
+// space left in the parent as minimal list of prefixes.
+type VLSMPlan struct {
+	Allocations []Allocation
+	Free        []IP
+}
+
+// ParseDemand parse "name=hosts" demand, e.g. office=120.
+func ParseDemand(s string) (Demand, error) {
+	name, hosts, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return Demand{}, fmt.Errorf("invalid demand %q, expected name=hosts", s)
+	}
+	n, err := strconv.ParseUint(hosts, 10, 64)
+	if err != nil || n == 0 {
+		return Demand{}, fmt.Errorf("invalid number of hosts in %q", s)
+	}
+	return Demand{Name: name, Hosts: n}, nil
+}
+
+// VLSM allocate for each demand the smallest subnet of parent with
+// enough usable hosts (see GetUsableHosts, so 1 or 2 hosts get /31 or
+// /127, host routes are never given). Subnets are given largest-first,
+// one after another, which keep every subnet aligned and never overlap.
+// Demand names must be unique. Error tells how many addresses are
+// missing when demands don't fit in the parent.
+func VLSM(parent IP, demands []Demand) (VLSMPlan, error) {
+	var plan VLSMPlan
+	n := len(parent.Addr)
+	bits := uint8(n * 16)
+
+	subnets := make([]Allocation, 0, len(demands))
+	need := new(big.Int)
+	names := make(map[string]bool, len(demands))
+	for _, d := range demands {
+		if d.Hosts == 0 {
+			return plan, fmt.Errorf("%s: number of hosts must be above 0", d.Name)
+		}
+		if names[d.Name] {
+			return plan, fmt.Errorf("%s: duplicate demand name", d.Name)
+		}
+		names[d.Name] = true
+		hosts := new(big.Int).SetUint64(d.Hosts)
+		pfx := bits - 1
+		for ; pfx > parent.Pfx; pfx-- {
+			if newIP(parent.Addr, pfx).GetUsableHosts().Cmp(hosts) >= 0 {
+				break
+			}
+		}
+		sub := newIP(parent.Addr, pfx)
+		if sub.GetUsableHosts().Cmp(hosts) < 0 {
+			return plan, fmt.Errorf("%s: %d hosts don't fit in %s, it has only %s usable hosts",
+				d.Name, d.Hosts, parent.GetAddrMask(), sub.GetUsableHosts())
+		}
+		need.Add(need, sub.GetHostsNumber())
+		subnets = append(subnets, Allocation{Demand: d, IP: sub})
+	}
+
+	size := parent.GetHostsNumber()
+	if need.Cmp(size) > 0 {
+		return plan, fmt.Errorf("demands need %s addresses, but %s has only %s",
+			need, parent.GetAddrMask(), size)
+	}
+
+	// largest first, equal ones in the given order
+	sort.SliceStable(subnets, func(i, j int) bool {
+		return subnets[i].Pfx < subnets[j].Pfx
+	})
+
+	cur := addrToBig(parent.GetFirstAddr())
+	for i := range subnets {
+		subnets[i].IP = newIP(bigToAddr(cur, n), subnets[i].Pfx)
+		cur.Add(cur, subnets[i].GetHostsNumber())
+	}
+	plan.Allocations = subnets
+
+	last := addrToBig(parent.GetLastAddr())
+	if cur.Cmp(last) <= 0 {
+		plan.Free = rangeToPrefixes(cur, last, n)
+	}
+	return plan, nil
+}
+
+// Demanded return the demand of the allocation, false for free space.
+func (a Allocation) Demanded() (Demand, bool) {
+	return a.Demand, a.Name != ""
+}
+
+// Waste return percent of usable hosts of the subnet not needed by the
+// demand.
+func (a Allocation) Waste() float64 {
+	usable, _ := new(big.Float).SetInt(a.GetUsableHosts()).Float64()
+	return (usable - float64(a.Hosts)) / usable * 100
+}
+
+// Pretty return allocation rows, name and hosts needed before the
+// subnet rows and usable range. Free space is marked by "Free space" row.
+func (a Allocation) Pretty(f Format) [][2]string {
+	if a.Name == "" {
+		return append(a.IP.Pretty(f), [2]string{"Free space", "yes"})
+	}
+
+	result := [][2]string{
+		{"Name", a.Name},
+		{"Hosts needed", formatBigInt(new(big.Int).SetUint64(a.Hosts), f.Pretty)},
+	}
+	result = append(result, a.IP.Pretty(f)...)
+	// detail rows have them already
+	if !f.Detail {
+		result = append(result,
+			[2]string{"HostMin", f.Addr(a.GetHostMin())},
+			[2]string{"HostMax", f.Addr(a.GetHostMax())},
+			[2]string{"Usable hosts", a.GetUsableHostsStr(f.Pretty)},
+		)
+	}
+	return append(result, [2]string{"Waste", strconv.FormatFloat(a.Waste(), 'f', 2, 64) + "%"})
+}
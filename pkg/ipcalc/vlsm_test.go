@@ -0,0 +1,149 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"strings"
+	"testing"
+)
+
+var testCasesVLSM = []struct {
+	parent   string
+	demands  string
+	expAlloc []string // name=subnet in address order
+	expFree  []string
+	expErr   bool
+}{
+	{
+		"192.168.1.0/24", "office=120 voip=50 mgmt=10 p2p=2",
+		[]string{"office=192.168.1.0/25", "voip=192.168.1.128/26", "mgmt=192.168.1.192/28", "p2p=192.168.1.208/31"},
+		[]string{"192.168.1.210/31", "192.168.1.212/30", "192.168.1.216/29", "192.168.1.224/27"},
+		false,
+	},
+	// equal subnets keep the given order
+	{
+		"10.0.0.0/29", "a=2 b=2 c=2 d=2",
+		[]string{"a=10.0.0.0/31", "b=10.0.0.2/31", "c=10.0.0.4/31", "d=10.0.0.6/31"},
+		nil,
+		false,
+	},
+	// 126 usable hosts in /25, 127 need /24
+	{
+		"10.0.0.0/24", "a=127",
+		[]string{"a=10.0.0.0/24"},
+		nil,
+		false,
+	},
+	// one host get the smallest subnet with usable host, not a host route
+	{
+		"10.0.0.0/24", "small=1 big=126",
+		[]string{"big=10.0.0.0/25", "small=10.0.0.128/31"},
+		[]string{"10.0.0.130/31", "10.0.0.132/30", "10.0.0.136/29", "10.0.0.144/28", "10.0.0.160/27", "10.0.0.192/26"},
+		false,
+	},
+	{"2001:db8::/126", "a=1", []string{"a=2001:db8::/127"}, []string{"2001:db8::2/127"}, false},
+	// parent address is not the network one
+	{
+		"10.0.0.77/26", "lan=30",
+		[]string{"lan=10.0.0.64/27"},
+		[]string{"10.0.0.96/27"},
+		false,
+	},
+	{
+		"2001:db8::/116", "lan=1000 p2p=2 mgmt=100",
+		[]string{"lan=2001:db8::/118", "mgmt=2001:db8::400/121", "p2p=2001:db8::480/127"},
+		[]string{
+			"2001:db8::482/127", "2001:db8::484/126", "2001:db8::488/125",
+			"2001:db8::490/124", "2001:db8::4a0/123", "2001:db8::4c0/122",
+			"2001:db8::500/120", "2001:db8::600/119", "2001:db8::800/117",
+		},
+		false,
+	},
+
+	// don't fit
+	{"192.168.1.0/24", "office=200 voip=60", nil, nil, true},
+	{"192.168.1.0/24", "office=255", nil, nil, true},
+	{"10.0.0.0/30", "a=2 b=2 c=1", nil, nil, true},
+	{"2001:db8::/120", "lan=256", nil, nil, true},
+	{"10.0.0.1/32", "a=1", nil, nil, true},
+
+	// duplicate name
+	{"10.0.0.0/24", "a=10 b=10 a=20", nil, nil, true},
+}
+
+func TestVLSM(t *testing.T) {
+	for _, tt := range testCasesVLSM {
+		ips, _, err := ipcalc.Parse(tt.parent, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.parent, err)
+			continue
+		}
+		var demands []ipcalc.Demand
+		for _, s := range strings.Fields(tt.demands) {
+			d, err := ipcalc.ParseDemand(s)
+			if err != nil {
+				t.Fatalf("%q unexpected error: %v", s, err)
+			}
+			demands = append(demands, d)
+		}
+
+		plan, err := ipcalc.VLSM(ips[0], demands)
+		if (err != nil) != tt.expErr {
+			t.Errorf("%q %q error got %v, want error %v", tt.parent, tt.demands, err, tt.expErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		var alloc, free []string
+		for _, a := range plan.Allocations {
+			alloc = append(alloc, a.Name+"="+a.GetAddrMask())
+		}
+		for _, ip := range plan.Free {
+			free = append(free, ip.GetAddrMask())
+		}
+		if strings.Join(alloc, " ") != strings.Join(tt.expAlloc, " ") {
+			t.Errorf("%q %q allocations got %v, want %v", tt.parent, tt.demands, alloc, tt.expAlloc)
+		}
+		if strings.Join(free, " ") != strings.Join(tt.expFree, " ") {
+			t.Errorf("%q %q free got %v, want %v", tt.parent, tt.demands, free, tt.expFree)
+		}
+	}
+}
+
+var testCasesDemand = []struct {
+	input  string
+	exp    ipcalc.Demand
+	expErr bool
+}{
+	{"office=120", ipcalc.Demand{Name: "office", Hosts: 120}, false},
+	{"p2p=2", ipcalc.Demand{Name: "p2p", Hosts: 2}, false},
+	{"=2", ipcalc.Demand{}, true},
+	{"office", ipcalc.Demand{}, true},
+	{"office=0", ipcalc.Demand{}, true},
+	{"office=-1", ipcalc.Demand{}, true},
+	{"office=ten", ipcalc.Demand{}, true},
+}
+
+func TestParseDemand(t *testing.T) {
+	for _, tt := range testCasesDemand {
+		d, err := ipcalc.ParseDemand(tt.input)
+		if (err != nil) != tt.expErr || d != tt.exp {
+			t.Errorf("%q got %v %v, want %v error %v", tt.input, d, err, tt.exp, tt.expErr)
+		}
+	}
+}
+
+func TestAllocationWaste(t *testing.T) {
+	ips, _, _ := ipcalc.Parse("192.168.1.0/24", ipcalc.ParseOptions{})
+	plan, err := ipcalc.VLSM(ips[0], []ipcalc.Demand{{Name: "office", Hosts: 120}, {Name: "p2p", Hosts: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 120 of 126 usable hosts, 2 of 2
+	for i, exp := range []float64{100 * 6.0 / 126, 0} {
+		if w := plan.Allocations[i].Waste(); w-exp > 1e-9 || exp-w > 1e-9 {
+			t.Errorf("%s waste got %f, want %f", plan.Allocations[i].Name, w, exp)
+		}
+	}
+}
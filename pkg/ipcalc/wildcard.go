@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package ipcalc
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// WildcardMatch represent ACL style address with wildcard (inverse) mask,
+// where every set bit of wildcard is a "don't care" bit. Unlike IP the
+// wildcard can be non-contiguous, e.g. 10.0.0.1 0.0.255.0.
+type WildcardMatch struct {
+	Addr     []uint16
+	Wildcard []uint16
+}
+
+// ParseIPv4Wildcard parse "x.x.x.x w.w.w.w" or "x.x.x.x/w.w.w.w" ACL
+// notation to WildcardMatch.
+func ParseIPv4Wildcard(s string) (WildcardMatch, error) {
+	var out WildcardMatch
+
+	addr, wStr, ok := strings.Cut(s, "/")
+	if !ok {
+		f := strings.Fields(s)
+		if len(f) != 2 {
+			p := fmt.Errorf("invalid addr, expected <ipv4> <wildcard>, given: %s", s)
+			return out, p
+		}
+		addr, wStr = f[0], f[1]
+	}
+	if strings.Count(wStr, ".") != 3 {
+		return out, fmt.Errorf("invalid wildcard: %q", wStr)
+	}
+
+	ip, err := parseOctets(addr)
+	if err != nil {
+		return out, err
+	}
+	w, err := parseOctets(wStr)
+	if err != nil {
+		return out, fmt.Errorf("invalid wildcard: %q", wStr)
+	}
+
+	out.Addr = ip
+	out.Wildcard = w
+	return out, nil
+}
+
+// IsIPv4Wildcard reports whether s looks like IPv4 wildcard (inverse)
+// mask: contiguous host bits like 0.0.0.255, or non-contiguous one with
+// the first octet 0 like 0.0.255.0. Other dotted quads are rather
+// addresses, so "10.0.0.1 10.0.0.2" is not a wildcard pair.
+func IsIPv4Wildcard(s string) bool {
+	if strings.Count(s, ".") != 3 {
+		return false
+	}
+	w, err := parseOctets(s)
+	if err != nil {
+		return false
+	}
+	v := uint32(w[0])<<16 | uint32(w[1])
+	return v>>24 == 0 || v&(v+1) == 0
+}
+
+// Prefix convert match to IP when wildcard is contiguous (only host bits
+// are "don't care"). The second value is false for non-contiguous wildcard.
+func (w WildcardMatch) Prefix() (IP, bool) {
+	v := ^(uint32(w.Wildcard[0])<<16 | uint32(w.Wildcard[1]))
+	pfx := uint8(bits.LeadingZeros32(^v))
+	if v<<pfx != 0 {
+		return IP{}, false
+	}
+	return IP{
+		Addr: append([]uint16(nil), w.Addr...),
+		Mask: prefixToMaskIPv4(pfx),
+		Pfx:  pfx,
+	}, true
+}
+
+// Pretty return match description in the same form as IP.Pretty.
+func (w WildcardMatch) Pretty(f Format) [][2]string {
+	first := make([]uint16, len(w.Addr))
+	last := make([]uint16, len(w.Addr))
+	mask := make([]uint16, len(w.Addr))
+	dontCare := 0
+	for i := range w.Addr {
+		first[i] = w.Addr[i] &^ w.Wildcard[i]
+		last[i] = w.Addr[i] | w.Wildcard[i]
+		mask[i] = ^w.Wildcard[i]
+		dontCare += bits.OnesCount16(w.Wildcard[i])
+	}
+
+	result := [][2]string{
+		{"Full address", NiceAddr(w.Addr) + " " + NiceAddr(w.Wildcard)},
+		{"First match", NiceAddr(first)},
+		{"Last match", NiceAddr(last)},
+	}
+
+	if f.Detail {
+		matches := new(big.Int).Lsh(big.NewInt(1), uint(dontCare))
+		n := matches.String()
+		if f.Pretty {
+			n = formatBigIntWithSpaces(matches)
+		}
+		tmp := [][2]string{
+			{"Address", NiceAddr(w.Addr)},
+			{"Wildcard mask", NiceAddr(w.Wildcard)},
+			{"Mask address", NiceAddr(mask)},
+			{"Matched addresses", n},
+		}
+		result = append(result, tmp...)
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package ipcalc_test
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"testing"
+)
+
+var testCasesWildcard = []struct {
+	input       string
+	expAddr     []uint16
+	expWildcard []uint16
+	expPfx      uint8
+	contiguous  bool
+}{
+	// valid
+	{"10.1.0.0 0.0.255.255", []uint16{0x0a01, 0x0000}, []uint16{0x0000, 0xffff}, 16, true},
+	{"10.1.0.0/0.0.0.255", []uint16{0x0a01, 0x0000}, []uint16{0x0000, 0x00ff}, 24, true},
+	{"10.1.0.1 0.0.0.0", []uint16{0x0a01, 0x0001}, []uint16{0x0000, 0x0000}, 32, true},
+	{"0.0.0.0 255.255.255.255", []uint16{0x0000, 0x0000}, []uint16{0xffff, 0xffff}, 0, true},
+	{"10.1.0.1 0.0.255.0", []uint16{0x0a01, 0x0001}, []uint16{0x0000, 0xff00}, 0, false},
+	{"10.0.0.0 0.255.0.255", []uint16{0x0a00, 0x0000}, []uint16{0x00ff, 0x00ff}, 0, false},
+
+	// invalid
+	{"10.1.0.0", nil, nil, 0, false},             // no wildcard
+	{"10.1.0.0 0.0.255", nil, nil, 0, false},     // short wildcard
+	{"10.1.0.0 0.0.256.255", nil, nil, 0, false}, // wrong octet
+	{"10.1.0.0/16", nil, nil, 0, false},          // prefix length
+}
+
+func TestParseIPv4Wildcard(t *testing.T) {
+	for _, tt := range testCasesWildcard {
+		w, err := ipcalc.ParseIPv4Wildcard(tt.input)
+		if tt.expAddr == nil {
+			if err == nil {
+				t.Errorf("%q expected error, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q unexpected error: %v", tt.input, err)
+			continue
+		}
+		if !EqualU16(w.Addr, tt.expAddr) {
+			t.Errorf("%q addr got %x, want %x", tt.input, w.Addr, tt.expAddr)
+		}
+		if !EqualU16(w.Wildcard, tt.expWildcard) {
+			t.Errorf("%q wildcard got %x, want %x", tt.input, w.Wildcard, tt.expWildcard)
+		}
+
+		ip, ok := w.Prefix()
+		if ok != tt.contiguous {
+			t.Errorf("%q contiguous got %v, want %v", tt.input, ok, tt.contiguous)
+			continue
+		}
+		if ok && ip.Pfx != tt.expPfx {
+			t.Errorf("%q prefix got %d, want %d", tt.input, ip.Pfx, tt.expPfx)
+		}
+	}
+}
+
+var testCasesIsWildcard = []struct {
+	input string
+	exp   bool
+}{
+	{"0.0.0.255", true},
+	{"0.0.255.255", true},
+	{"0.0.0.0", true},
+	{"255.255.255.255", true},
+	{"0.255.0.255", true},
+	{"0.0.255.0", true},
+	{"1.255.255.255", true},
+
+	{"10.0.0.2", false},      // an address
+	{"255.255.255.0", false}, // a mask
+	{"255.0.255.0", false},
+	{"0.0.255", false},
+	{"0.0.256.0", false},
+	{"24", false},
+}
+
+func TestIsIPv4Wildcard(t *testing.T) {
+	for _, tt := range testCasesIsWildcard {
+		if r := ipcalc.IsIPv4Wildcard(tt.input); r != tt.exp {
+			t.Errorf("%q got %v, want %v", tt.input, r, tt.exp)
+		}
+	}
+}
@@ -11,12 +11,44 @@ import (
 	"encoding/json"
 	"fmt"
 	"goipcalc/pkg/ipcalc"
+	"math"
 	"math/big"
 	"os"
 	"strconv"
 	"text/tabwriter"
 )
 
+// Prettier is implemented by every value PrintOutput can render,
+// like ipcalc.IP and ipcalc.WildcardMatch.
+type Prettier interface {
+	Pretty(f ipcalc.Format) [][2]string
+}
+
+// Classifier is implemented by results with the "Type" row, like
+// ipcalc.IP and types embedding it. It fill the JSON type object.
+type Classifier interface {
+	Classify() ipcalc.Class
+}
+
+// MulticastDecoder is implemented by results with the "Multicast flags"
+// row, like ipcalc.IP. It fill the JSON multicast object.
+type MulticastDecoder interface {
+	DecodeMulticast() (ipcalc.Multicast, bool)
+}
+
+// Allocator is implemented by VLSM results, like ipcalc.Allocation.
+// It fill the JSON name, hosts needed, waste and free fields.
+type Allocator interface {
+	Demanded() (ipcalc.Demand, bool)
+	Waste() float64
+}
+
+// Normalizer is implemented by results parsed from normalized input
+// (see ipcalc.Normalization). It fill the JSON normalized field.
+type Normalizer interface {
+	Normalization() ipcalc.Normalization
+}
+
 // JSONOut represent structured version of complete IPOut list and errors
 // This type is used for stable JSON output.
 type JSONOut struct {
@@ -27,36 +59,119 @@ type JSONOut struct {
 // IPOut represents a structured version of IP address calculation
 // results. This type is used for stable JSON encoding output.
 type IPOut struct {
-	FullAddress string   `json:"full_address"`
-	Network     string   `json:"network,omitempty"`
-	Broadcast   string   `json:"broadcast,omitempty"`
-	LastAddress string   `json:"last_address,omitempty"`
-	Address     string   `json:"address,omitempty"`
-	Mask        int      `json:"mask,omitempty"`
-	MaskAddress string   `json:"mask_address,omitempty"`
-	HostsNumber *big.Int `json:"hosts_number,omitempty"`
+	Name             string    `json:"name,omitempty"`
+	HostsNeeded      uint64    `json:"hosts_needed,omitempty"`
+	FullAddress      string    `json:"full_address"`
+	Normalized       string    `json:"normalized,omitempty"`
+	Network          string    `json:"network,omitempty"`
+	Broadcast        string    `json:"broadcast,omitempty"`
+	LastAddress      string    `json:"last_address,omitempty"`
+	FirstMatch       string    `json:"first_match,omitempty"`
+	LastMatch        string    `json:"last_match,omitempty"`
+	Address          string    `json:"address,omitempty"`
+	Type             *TypeOut  `json:"type,omitempty"`
+	Classful         *ClassOut `json:"classful,omitempty"`
+	Multicast        *McastOut `json:"multicast,omitempty"`
+	MulticastMAC     string    `json:"multicast_mac,omitempty"`
+	MACOverlap       []string  `json:"mac_overlap,omitempty"`
+	Mask             int       `json:"mask,omitempty"`
+	MaskAddress      string    `json:"mask_address,omitempty"`
+	WildcardMask     string    `json:"wildcard_mask,omitempty"`
+	HostMin          string    `json:"host_min,omitempty"`
+	HostMax          string    `json:"host_max,omitempty"`
+	TotalAddresses   *big.Int  `json:"total_addresses,omitempty"`
+	UsableHosts      *big.Int  `json:"usable_hosts,omitempty"`
+	HostsNumber      *big.Int  `json:"hosts_number,omitempty"` // deprecated, equal to TotalAddresses
+	AddressInt       *big.Int  `json:"address_int,omitempty"`
+	AddressHex       string    `json:"address_hex,omitempty"`
+	NetworkInt       *big.Int  `json:"network_int,omitempty"`
+	NetworkHex       string    `json:"network_hex,omitempty"`
+	LastAddressInt   *big.Int  `json:"last_address_int,omitempty"`
+	LastAddressHex   string    `json:"last_address_hex,omitempty"`
+	ReverseZones     []string  `json:"reverse_zones,omitempty"`
+	MatchedAddresses *big.Int  `json:"matched_addresses,omitempty"`
+	WastePercent     *float64  `json:"waste_percent,omitempty"`
+	Free             bool      `json:"free,omitempty"`
+	Binary           *BinOut   `json:"binary,omitempty"`
+}
+
+// TypeOut represents ipcalc.Class of the address, category and flags
+// from IANA special-purpose registry.
+type TypeOut struct {
+	Category          string `json:"category"`
+	Name              string `json:"name,omitempty"`
+	Block             string `json:"block,omitempty"`
+	RFC               string `json:"rfc,omitempty"`
+	Forwardable       bool   `json:"forwardable"`
+	GloballyReachable *bool  `json:"globally_reachable"` // null when unknown
+}
+
+// ClassOut represents classful information of IPv4 address, see
+// ipcalc.ClassfulInfo.
+type ClassOut struct {
+	Class   string `json:"class"`
+	Mask    string `json:"mask,omitempty"`
+	Network string `json:"network,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// McastOut represents decoded IPv6 multicast address, see
+// ipcalc.DecodeMulticast.
+type McastOut struct {
+	R              bool     `json:"r"`
+	P              bool     `json:"p"`
+	T              bool     `json:"t"`
+	Scope          int      `json:"scope"`
+	ScopeName      string   `json:"scope_name"`
+	GroupID        string   `json:"group_id"`
+	EmbeddedPrefix string   `json:"embedded_prefix,omitempty"`
+	RPAddress      string   `json:"rp_address,omitempty"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// BinOut represents binary view of the IP, with network/host split
+// marked by ipcalc.BinaryMarker.
+type BinOut struct {
+	Address     string `json:"address"`
+	Mask        string `json:"mask"`
+	Network     string `json:"network"`
+	LastAddress string `json:"last_address"`
+}
+
+// binary return o.Binary, allocated on first use.
+func (o *IPOut) binary() *BinOut {
+	if o.Binary == nil {
+		o.Binary = &BinOut{}
+	}
+	return o.Binary
 }
 
 // nicePrintCLI formats and writes the IP address calculation results
 // in a human-readable CLI table format.
 //
-// It uses the tabwriter to align output in columns. If `detail` is true,
+// It uses the tabwriter to align output in columns. If `f.Detail` is true,
 // additional information such as mask and host count is included.
 //
 // Example output:
 // ---
-// Full address:  10.0.1.1/24
-// Network:       10.0.1.0
-// Broadcast:     10.0.1.255
-// Address:       10.0.1.1
-// Mask:          24
-// Mask address:  255.255.255.0
-// Hosts number:  256
-func nicePrintCLI(b *bytes.Buffer, ipList []ipcalc.IP, detail bool) error {
+// Full address:       10.0.1.1/24
+// Network:            10.0.1.0
+// Broadcast:          10.0.1.255
+// Address:            10.0.1.1
+// Mask:               24
+// Mask address:       255.255.255.0
+// Wildcard mask:      0.0.0.255
+// HostMin:            10.0.1.1
+// HostMax:            10.0.1.254
+// Total addresses:    256
+// Usable hosts:       254
+// ...
+func nicePrintCLI(b *bytes.Buffer, ipList []Prettier, f ipcalc.Format) error {
 	tw := tabwriter.NewWriter(b, 0, 0, 2, ' ', tabwriter.StripEscape)
 
+	f.Pretty = true
 	for _, p := range ipList {
-		items := p.Pretty(detail, true)
+		items := p.Pretty(f)
 		fmt.Fprintf(tw, "---\n")
 		for _, kv := range items {
 			fmt.Fprintf(tw, "%s:\t%s\n", kv[0], kv[1])
@@ -70,42 +185,17 @@ func nicePrintCLI(b *bytes.Buffer, ipList []ipcalc.IP, detail bool) error {
 // nicePrintJSON encodes IP address calculation results as JSON
 // and writes them to the provided writer.
 //
-// If `indent` is true, the JSON is pretty-printed with indentation.
-// The `detail` flag controls whether additional fields (mask, hosts, etc.)
+// If `i` is true, the JSON is pretty-printed with indentation.
+// The `f.Detail` flag controls whether additional fields (mask, hosts, etc.)
 // are included in the output.
-func nicePrintJSON(buf *bytes.Buffer, ips []ipcalc.IP, errs []string, d, i bool) error {
+func nicePrintJSON(buf *bytes.Buffer, ips []Prettier, errs []string, f ipcalc.Format, i bool) error {
 	out := JSONOut{
 		Results: make([]IPOut, 0, len(ips)),
 		Errors:  errs,
 	}
 
 	for _, ip := range ips {
-		var o IPOut
-		for _, kv := range ip.Pretty(d, false) {
-			switch kv[0] {
-			case "Full address":
-				o.FullAddress = kv[1]
-			case "Network":
-				o.Network = kv[1]
-			case "Broadcast":
-				o.Broadcast = kv[1]
-			case "Last address":
-				o.LastAddress = kv[1]
-			case "Address":
-				o.Address = kv[1]
-			case "Mask":
-				if v, err := strconv.Atoi(kv[1]); err == nil {
-					o.Mask = v
-				}
-			case "Mask address":
-				o.MaskAddress = kv[1]
-			case "Hosts number":
-				if v, ok := new(big.Int).SetString(kv[1], 10); ok {
-					o.HostsNumber = v
-				}
-			}
-		}
-		out.Results = append(out.Results, o)
+		out.Results = append(out.Results, newIPOut(ip, f))
 	}
 
 	enc := json.NewEncoder(buf)
@@ -121,6 +211,144 @@ func nicePrintJSON(buf *bytes.Buffer, ips []ipcalc.IP, errs []string, d, i bool)
 	return nil
 }
 
+// newIPOut map Pretty rows of ip to IPOut fields.
+func newIPOut(ip Prettier, f ipcalc.Format) IPOut {
+	var o IPOut
+	if v, ok := ip.(Normalizer); ok {
+		o.Normalized = v.Normalization().String()
+	}
+	if v, ok := ip.(Allocator); ok {
+		if d, ok := v.Demanded(); ok {
+			// same precision as the CLI row
+			w := math.Round(v.Waste()*100) / 100
+			o.Name, o.HostsNeeded, o.WastePercent = d.Name, d.Hosts, &w
+		} else {
+			o.Free = true
+		}
+	}
+	f.Pretty = false
+	for _, kv := range ip.Pretty(f) {
+		switch kv[0] {
+		case "Full address":
+			o.FullAddress = kv[1]
+		case "Network":
+			o.Network = kv[1]
+		case "Broadcast":
+			o.Broadcast = kv[1]
+		case "Last address":
+			o.LastAddress = kv[1]
+		case "First match":
+			o.FirstMatch = kv[1]
+		case "Last match":
+			o.LastMatch = kv[1]
+		case "Address":
+			o.Address = kv[1]
+		case "Type":
+			if v, ok := ip.(Classifier); ok {
+				c := v.Classify()
+				o.Type = &TypeOut{
+					Category:    c.Category,
+					Name:        c.Name,
+					Block:       c.Block,
+					RFC:         c.RFC,
+					Forwardable: c.Forwardable,
+				}
+				if !c.ReachabilityUnknown {
+					o.Type.GloballyReachable = &c.GloballyReachable
+				}
+			}
+		case "Class":
+			o.Classful = &ClassOut{Class: kv[1]}
+		case "Classful mask":
+			if o.Classful != nil {
+				o.Classful.Mask = kv[1]
+			}
+		case "Classful network":
+			if o.Classful != nil {
+				o.Classful.Network = kv[1]
+			}
+		case "Classful status":
+			if o.Classful != nil {
+				o.Classful.Status = kv[1]
+			}
+		case "Multicast flags":
+			if v, ok := ip.(MulticastDecoder); ok {
+				if m, ok := v.DecodeMulticast(); ok {
+					o.Multicast = &McastOut{
+						R:         m.R,
+						P:         m.P,
+						T:         m.T,
+						Scope:     int(m.Scope),
+						ScopeName: m.ScopeName(),
+						GroupID:   fmt.Sprintf("0x%x", m.GroupID),
+						Errors:    m.Errors,
+					}
+				}
+			}
+		case "Embedded prefix":
+			if o.Multicast != nil {
+				o.Multicast.EmbeddedPrefix = kv[1]
+			}
+		case "RP address":
+			if o.Multicast != nil {
+				o.Multicast.RPAddress = kv[1]
+			}
+		case "Multicast MAC":
+			o.MulticastMAC = kv[1]
+		case "MAC overlap":
+			o.MACOverlap = append(o.MACOverlap, kv[1])
+		case "Mask":
+			if v, err := strconv.Atoi(kv[1]); err == nil {
+				o.Mask = v
+			}
+		case "Mask address":
+			o.MaskAddress = kv[1]
+		case "Wildcard mask":
+			o.WildcardMask = kv[1]
+		case "HostMin":
+			o.HostMin = kv[1]
+		case "HostMax":
+			o.HostMax = kv[1]
+		case "Total addresses":
+			if v, ok := new(big.Int).SetString(kv[1], 10); ok {
+				o.TotalAddresses = v
+				o.HostsNumber = v
+			}
+		case "Usable hosts":
+			if v, ok := new(big.Int).SetString(kv[1], 10); ok {
+				o.UsableHosts = v
+			}
+		case "Address integer":
+			o.AddressInt, _ = new(big.Int).SetString(kv[1], 10)
+		case "Address hex":
+			o.AddressHex = kv[1]
+		case "Network integer":
+			o.NetworkInt, _ = new(big.Int).SetString(kv[1], 10)
+		case "Network hex":
+			o.NetworkHex = kv[1]
+		case "Broadcast integer", "Last address integer":
+			o.LastAddressInt, _ = new(big.Int).SetString(kv[1], 10)
+		case "Broadcast hex", "Last address hex":
+			o.LastAddressHex = kv[1]
+		case "Reverse zone":
+			o.ReverseZones = append(o.ReverseZones, kv[1])
+		case "Matched addresses":
+			if v, ok := new(big.Int).SetString(kv[1], 10); ok {
+				o.MatchedAddresses = v
+			}
+		case "Binary address":
+			o.binary().Address = kv[1]
+		case "Binary mask":
+			o.binary().Mask = kv[1]
+		case "Binary network":
+			o.binary().Network = kv[1]
+		case "Binary broadcast", "Binary last address":
+			o.binary().LastAddress = kv[1]
+		}
+	}
+	return o
+}
+
 // errorsCLI writes a list of error messages to the given writer.
 // It is used to output errors in a simple, human-readable format.
 //
@@ -139,9 +367,10 @@ func errorsCLI(buf *bytes.Buffer, errs []string) {
 //   - JSON mode still writes human-readable errors to stderr; stdout is JSON
 //     (or "[]\n" if there are no results).
 func PrintOutput(
-	jsonOut, jsonIndent, d bool,
+	jsonOut, jsonIndent bool,
+	f ipcalc.Format,
 	errList []string,
-	ipList []ipcalc.IP,
+	ipList []Prettier,
 ) (int, error) {
 	outBuf := &bytes.Buffer{}
 	errBuf := &bytes.Buffer{}
@@ -161,14 +390,14 @@ func PrintOutput(
 	// handel corect output
 	if jsonOut {
 		if hadResults {
-			if err := nicePrintJSON(outBuf, ipList, errList, d, jsonIndent); err != nil {
+			if err := nicePrintJSON(outBuf, ipList, errList, f, jsonIndent); err != nil {
 				return 1, err
 			}
 		} else {
 			outBuf.WriteString("[]\n")
 		}
 	} else {
-		if err := nicePrintCLI(outBuf, ipList, d); err != nil {
+		if err := nicePrintCLI(outBuf, ipList, f); err != nil {
 			return 1, err
 		}
 	}
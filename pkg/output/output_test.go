@@ -0,0 +1,144 @@
+package output
+
+import (
+	"goipcalc/pkg/ipcalc"
+	"strconv"
+	"testing"
+)
+
+func TestNewIPOutAllocation(t *testing.T) {
+	ips, _, err := ipcalc.Parse("10.0.0.0/24", ipcalc.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ipcalc.VLSM(ips[0], []ipcalc.Demand{{Name: "a", Hosts: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := newIPOut(plan.Allocations[0], ipcalc.Format{Detail: true})
+	if o.Name != "a" || o.FullAddress != "10.0.0.0/28" || o.HostsNeeded != 10 {
+		t.Errorf("got name %q, address %q, hosts %d", o.Name, o.FullAddress, o.HostsNeeded)
+	}
+	if o.Type == nil {
+		t.Fatal("type not set")
+	}
+	if o.Type.Category != ipcalc.CategoryPrivate || o.Type.Block != "10.0.0.0/8" {
+		t.Errorf("type got %+v, want private 10.0.0.0/8", *o.Type)
+	}
+	if o.Classful == nil || o.Classful.Class != "A" {
+		t.Errorf("classful got %+v, want class A", o.Classful)
+	}
+
+	// no detail, no type
+	if o := newIPOut(plan.Allocations[0], ipcalc.Format{}); o.Type != nil {
+		t.Errorf("type without detail got %+v", *o.Type)
+	}
+}
+
+func TestNewIPOutAllocationMulticast(t *testing.T) {
+	ips, _, err := ipcalc.Parse("ff3e:30:2001:db8::/96", ipcalc.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ipcalc.VLSM(ips[0], []ipcalc.Demand{{Name: "video", Hosts: 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := newIPOut(plan.Allocations[0], ipcalc.Format{Detail: true})
+	if o.Multicast == nil {
+		t.Fatal("multicast not set")
+	}
+	m := *o.Multicast
+	if !m.P || !m.T || m.R || m.ScopeName != "global" || m.EmbeddedPrefix != "2001:db8::/48" {
+		t.Errorf("multicast got %+v", m)
+	}
+}
+
+type normalized struct {
+	ipcalc.IP
+	norm ipcalc.Normalization
+}
+
+func (n normalized) Normalization() ipcalc.Normalization { return n.norm }
+
+func TestNewIPOutNormalized(t *testing.T) {
+	ips, norm, err := ipcalc.Parse("[2001:db8::1]:443", ipcalc.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := newIPOut(normalized{IP: ips[0], norm: norm}, ipcalc.Format{})
+	if o.Normalized != "stripped brackets, stripped port" {
+		t.Errorf("normalized got %q", o.Normalized)
+	}
+	if o := newIPOut(ips[0], ipcalc.Format{}); o.Normalized != "" {
+		t.Errorf("normalized without Normalizer got %q", o.Normalized)
+	}
+}
+
+func TestNewIPOutHostsNumber(t *testing.T) {
+	ips, _, err := ipcalc.Parse("10.0.0.0/24", ipcalc.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := newIPOut(ips[0], ipcalc.Format{Detail: true})
+	if o.TotalAddresses == nil || o.HostsNumber == nil {
+		t.Fatalf("total %v, hosts number %v", o.TotalAddresses, o.HostsNumber)
+	}
+	// deprecated alias of total_addresses
+	if o.HostsNumber.Cmp(o.TotalAddresses) != 0 || o.TotalAddresses.Int64() != 256 {
+		t.Errorf("hosts number %v, total %v, want 256", o.HostsNumber, o.TotalAddresses)
+	}
+}
+
+func TestNewIPOutReachability(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		exp   string
+	}{
+		{"224.0.0.5", "null"},
+		{"ff0e::1", "true"},
+		{"10.0.0.1", "false"},
+	} {
+		ips, _, err := ipcalc.Parse(tt.input, ipcalc.ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := newIPOut(ips[0], ipcalc.Format{Detail: true})
+		if o.Type == nil {
+			t.Fatalf("%q type not set", tt.input)
+		}
+		got := "null"
+		if r := o.Type.GloballyReachable; r != nil {
+			got = strconv.FormatBool(*r)
+		}
+		if got != tt.exp {
+			t.Errorf("%q globally reachable got %s, want %s", tt.input, got, tt.exp)
+		}
+	}
+}
+
+func TestNewIPOutAllocationWasteFree(t *testing.T) {
+	ips, _, err := ipcalc.Parse("10.0.0.0/24", ipcalc.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ipcalc.VLSM(ips[0], []ipcalc.Demand{{Name: "a", Hosts: 13}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := newIPOut(plan.Allocations[0], ipcalc.Format{})
+	// 1 of 14 usable hosts of /28 is not needed
+	if o.WastePercent == nil || *o.WastePercent != 7.14 || o.Free {
+		t.Errorf("waste got %v, free %v, want 7.14", o.WastePercent, o.Free)
+	}
+
+	o = newIPOut(ipcalc.Allocation{IP: plan.Free[0]}, ipcalc.Format{})
+	if !o.Free || o.Name != "" || o.WastePercent != nil {
+		t.Errorf("free space got free %v, name %q, waste %v", o.Free, o.Name, o.WastePercent)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Mateusz Krupczyński
+// Licensed under the MIT License.
+// See LICENSE file in the project root for details.
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"goipcalc/pkg/ipcalc"
+	"io"
+	"iter"
+	"text/tabwriter"
+)
+
+// StreamOutput renders results from seq to w one by one, so very long
+// lists (like IPv6 split) are never held in memory. JSON output has the
+// same shape as the PrintOutput one, without errors.
+func StreamOutput(w io.Writer, jsonOut, jsonIndent bool, f ipcalc.Format, seq iter.Seq[Prettier]) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	if jsonOut {
+		err = streamJSON(bw, seq, f, jsonIndent)
+	} else {
+		err = streamCLI(bw, seq, f)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// streamCLI write results in the nicePrintCLI format. Line "---" ends
+// the tabwriter block, so each result is aligned and written alone.
+func streamCLI(w io.Writer, seq iter.Seq[Prettier], f ipcalc.Format) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.StripEscape)
+
+	f.Pretty = true
+	for p := range seq {
+		fmt.Fprintf(tw, "---\n")
+		for _, kv := range p.Pretty(f) {
+			fmt.Fprintf(tw, "%s:\t%s\n", kv[0], kv[1])
+		}
+	}
+	return tw.Flush()
+}
+
+// streamJSON write results as JSONOut, encoding one IPOut at a time.
+func streamJSON(w io.Writer, seq iter.Seq[Prettier], f ipcalc.Format, i bool) error {
+	open, sep, end, empty := `{"results":[`, ",", "]}\n", `{"results":[]}`+"\n"
+	if i {
+		open, sep, end = "{\n  \"results\": [\n    ", ",\n    ", "\n  ]\n}\n"
+		empty = "{\n  \"results\": []\n}\n"
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if i {
+		enc.SetIndent("    ", "  ")
+	}
+
+	n := 0
+	for p := range seq {
+		buf.Reset()
+		if err := enc.Encode(newIPOut(p, f)); err != nil {
+			return err
+		}
+		s := open
+		if n > 0 {
+			s = sep
+		}
+		n++
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+		if _, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))); err != nil {
+			return err
+		}
+	}
+
+	if n == 0 {
+		end = empty
+	}
+	_, err := io.WriteString(w, end)
+	return err
+}